@@ -0,0 +1,156 @@
+package pipedrive
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Activity is a PipeDrive Activity representation
+type Activity struct {
+	ID             int    `json:"id"`
+	Subject        string `json:"subject"`
+	Type           string `json:"type"`
+	Done           bool   `json:"done"`
+	DueDate        string `json:"due_date"`
+	DueTime        string `json:"due_time"`
+	Duration       string `json:"duration"`
+	UserID         int    `json:"user_id"`
+	DealID         int    `json:"deal_id"`
+	PersonID       int    `json:"person_id"`
+	OrganizationID int    `json:"org_id"`
+	Note           string `json:"note"`
+	// OwnerName is the name of the User the Activity is assigned to
+	OwnerName string        `json:"owner_name"`
+	CreatedAt PipedriveTime `json:"add_time"`
+	UpdatedAt PipedriveTime `json:"update_time"`
+	DoneAt    PipedriveTime `json:"marked_as_done_time"`
+}
+
+// DueAt returns when the Activity is due, in UTC like PipeDrive's due times.
+// It is the zero time when there is no due date.
+func (a Activity) DueAt() time.Time {
+	if a.DueTime != "" {
+		if due, err := time.Parse("2006-01-02 15:04", a.DueDate+" "+a.DueTime); err == nil {
+			return due
+		}
+	}
+	due, _ := time.Parse("2006-01-02", a.DueDate)
+	return due
+}
+
+// ActivityType is a PipeDrive ActivityType representation. KeyString is the
+// value to use as an Activity's Type.
+type ActivityType struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	KeyString string `json:"key_string"`
+	IconKey   string `json:"icon_key"`
+}
+
+// ListActivityTypes returns all ActivityTypes
+func (c *Client) ListActivityTypes() ([]ActivityType, error) {
+	var types []ActivityType
+	_, err := c.getEntity("/activityTypes", &types)
+	return types, err
+}
+
+// ListActivities returns a page of the Activities of a user due between
+// startDate and endDate (YYYY-MM-DD, either may be empty), starting at start.
+// A userID of 0 returns the Activities of all users and a limit of 0 uses the
+// API's default page size. The returned Pagination tells where the next page
+// starts.
+func (c *Client) ListActivities(userID int, startDate, endDate string, start, limit int) ([]Activity, Pagination, error) {
+	query := url.Values{}
+	query.Set("user_id", strconv.Itoa(userID))
+	if startDate != "" {
+		query.Set("start_date", startDate)
+	}
+	if endDate != "" {
+		query.Set("end_date", endDate)
+	}
+	query.Set("start", strconv.Itoa(start))
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var activities []Activity
+	resp, err := c.getEntity("/activities?"+query.Encode(), &activities)
+	return activities, resp.AdditionalData.Pagination, err
+}
+
+// CreateActivity creates a new Activity from the initialized Activity, linked
+// to each of its deal, person and organization that is set. DueDate and
+// DueTime must be formatted as YYYY-MM-DD and HH:MM when set. The Activity is
+// updated with what PipeDrive returns, such as its owner and timestamps.
+func (c *Client) CreateActivity(a *Activity) error {
+	if a.Subject == "" {
+		return errors.New("Activity requires a subject")
+	}
+	if a.DueDate != "" {
+		if _, err := time.Parse("2006-01-02", a.DueDate); err != nil {
+			return fmt.Errorf("Activity due date must be YYYY-MM-DD; got %q", a.DueDate)
+		}
+	}
+	if a.DueTime != "" {
+		if _, err := time.Parse("15:04", a.DueTime); err != nil {
+			return fmt.Errorf("Activity due time must be HH:MM; got %q", a.DueTime)
+		}
+	}
+
+	bodyData := map[string]interface{}{
+		"subject": a.Subject,
+	}
+	optional := map[string]string{
+		"type":     a.Type,
+		"due_date": a.DueDate,
+		"due_time": a.DueTime,
+		"duration": a.Duration,
+		"note":     a.Note,
+	}
+	for name, value := range optional {
+		if value != "" {
+			bodyData[name] = value
+		}
+	}
+	links := map[string]int{
+		"user_id":   c.ownerID(a.UserID),
+		"deal_id":   a.DealID,
+		"person_id": a.PersonID,
+		"org_id":    a.OrganizationID,
+	}
+	for name, id := range links {
+		if id != 0 {
+			bodyData[name] = id
+		}
+	}
+	if a.Done {
+		bodyData["done"] = 1
+	}
+
+	created := *a
+	if err := c.post("/activities", bodyData, &created); err != nil {
+		return err
+	}
+
+	*a = created
+	return nil
+}
+
+// UpdateActivity updates the given fields on an existing Activity
+func (c *Client) UpdateActivity(id int, fields map[string]interface{}) error {
+	if id < 1 {
+		return errors.New("Activity ID must be positive")
+	}
+
+	return c.put(fmt.Sprintf("/activities/%d", id), fields, nil)
+}
+
+// MarkActivityDone marks an existing Activity as done
+func (c *Client) MarkActivityDone(activityID int) error {
+	return c.UpdateActivity(activityID, map[string]interface{}{
+		"done": 1,
+	})
+}
@@ -0,0 +1,297 @@
+package pipedrive
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_ListActivities(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/activities?api_token=abc123&end_date=2017-11-30&limit=2&start=0&start_date=2017-11-01&user_id=3219426": activitiesResp,
+			},
+		},
+	})
+
+	activities, pagination, err := client.ListActivities(3219426, "2017-11-01", "2017-11-30", 0, 2)
+	if err != nil {
+		t.Errorf("Unexpected error listing activities: %+v", err)
+		return
+	}
+
+	expected := Pagination{Start: 0, Limit: 2, MoreItems: true, NextStart: 2}
+	if pagination != expected {
+		t.Errorf("Pagination want %+v; got %+v", expected, pagination)
+	}
+	if len(activities) != 2 {
+		t.Errorf("Expected 2 activities; got %d", len(activities))
+		return
+	}
+	if !activities[0].Done || activities[1].Done {
+		t.Errorf("Unexpected done flags: %+v", activities)
+	}
+	if activities[1].DueDate != "2017-11-20" || activities[1].DueTime != "14:00" {
+		t.Errorf("Unexpected due date: %+v", activities[1])
+	}
+}
+
+func Test_ListActivities_Empty(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/activities?api_token=abc123&start=0&user_id=0": `{"success": true, "data": null, "additional_data": {"pagination": {"start": 0, "limit": 100, "more_items_in_collection": false}}}`,
+			},
+		},
+	})
+
+	activities, pagination, err := client.ListActivities(0, "", "", 0, 0)
+	if err != nil {
+		t.Errorf("Unexpected error listing activities: %+v", err)
+	}
+	if len(activities) != 0 || pagination.MoreItems {
+		t.Errorf("Expected no activities; got %d (more: %t)", len(activities), pagination.MoreItems)
+	}
+}
+
+func Test_MarkActivityDone(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/activities/5?api_token=abc123": `{"success": true, "data": {"id": 5, "done": true}}`,
+			},
+			sent: sent,
+		},
+	})
+
+	if err := client.MarkActivityDone(5); err != nil {
+		t.Errorf("Unexpected error marking activity done: %+v", err)
+		return
+	}
+
+	expected := `{"done":1}`
+	if actual := sent["http://base/v1/activities/5?api_token=abc123"]; actual != expected {
+		t.Errorf("Update body want %s; got %s", expected, actual)
+	}
+}
+
+func Test_CreateActivity(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/activities?api_token=abc123": activityCreateResp,
+			},
+			sent: sent,
+		},
+		DefaultUserID: 3219426,
+	})
+
+	activity := Activity{
+		Subject:        "Demo",
+		Type:           "meeting",
+		DueDate:        "2017-11-20",
+		DueTime:        "14:00",
+		DealID:         1,
+		PersonID:       2,
+		OrganizationID: 3,
+	}
+	if err := client.CreateActivity(&activity); err != nil {
+		t.Errorf("Unexpected error creating activity: %+v", err)
+		return
+	}
+
+	if activity.ID != 7 {
+		t.Errorf("Expected activity ID 7; got %d", activity.ID)
+	}
+	if activity.OwnerName != "Chris Marshall" || activity.UserID != 3219426 {
+		t.Errorf("Unexpected activity owner: %+v", activity)
+	}
+	if !activity.CreatedAt.Equal(time.Date(2017, 11, 17, 9, 12, 1, 0, time.UTC)) {
+		t.Errorf("Unexpected activity creation time: %v", activity.CreatedAt)
+	}
+	if !activity.DueAt().Equal(time.Date(2017, 11, 20, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected activity due time: %v", activity.DueAt())
+	}
+	expected := `{"deal_id":1,"due_date":"2017-11-20","due_time":"14:00","org_id":3,"person_id":2,"subject":"Demo","type":"meeting","user_id":3219426}`
+	if actual := sent["http://base/v1/activities?api_token=abc123"]; actual != expected {
+		t.Errorf("Create body want %s; got %s", expected, actual)
+	}
+}
+
+func Test_CreateActivity_Invalid(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{},
+	})
+
+	for _, activity := range []Activity{
+		{DealID: 1},
+		{Subject: "Demo", DueDate: "20/11/2017"},
+		{Subject: "Demo", DueTime: "2pm"},
+	} {
+		if err := client.CreateActivity(&activity); err == nil {
+			t.Errorf("Expected error creating activity %+v", activity)
+		}
+	}
+}
+
+func Test_UpdateActivity_Errors(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/activities/6?api_token=abc123": `{"success": false, "error": "Activity not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/activities/6?api_token=abc123": http.StatusNotFound,
+			},
+		},
+	})
+
+	if err := client.UpdateActivity(0, map[string]interface{}{"done": 1}); err == nil {
+		t.Error("Expected error updating activity with no ID")
+	}
+	err := client.UpdateActivity(6, map[string]interface{}{"done": 1})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected not found API error; got %+v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found API error to match ErrNotFound; got %+v", err)
+	}
+}
+
+func Test_ListActivityTypes(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/activityTypes?api_token=abc123": activityTypesResp,
+			},
+		},
+	})
+
+	types, err := client.ListActivityTypes()
+	if err != nil {
+		t.Errorf("Unexpected error listing activity types: %+v", err)
+		return
+	}
+
+	expected := []ActivityType{
+		{ID: 1, Name: "Call", KeyString: "call", IconKey: "call"},
+		{ID: 2, Name: "Meeting", KeyString: "meeting", IconKey: "meeting"},
+	}
+	if len(types) != len(expected) {
+		t.Errorf("Expected %d activity types; got %d", len(expected), len(types))
+		return
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("Activity type want %+v; got %+v", expected[i], types[i])
+		}
+	}
+}
+
+const activityTypesResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"order_nr": 1,
+			"name": "Call",
+			"key_string": "call",
+			"icon_key": "call",
+			"active_flag": true,
+			"color": null,
+			"is_custom_flag": false,
+			"add_time": "2017-11-14 17:00:00",
+			"update_time": null
+		},
+		{
+			"id": 2,
+			"order_nr": 2,
+			"name": "Meeting",
+			"key_string": "meeting",
+			"icon_key": "meeting",
+			"active_flag": true,
+			"color": null,
+			"is_custom_flag": false,
+			"add_time": "2017-11-14 17:00:00",
+			"update_time": null
+		}
+	]
+}`
+
+const activityCreateResp = `{
+	"success": true,
+	"data": {
+		"id": 7,
+		"company_id": 2178381,
+		"user_id": 3219426,
+		"done": false,
+		"type": "meeting",
+		"due_date": "2017-11-20",
+		"due_time": "14:00",
+		"duration": "",
+		"add_time": "2017-11-17 09:12:01",
+		"update_time": "2017-11-17 09:12:01",
+		"marked_as_done_time": "",
+		"subject": "Demo",
+		"deal_id": 1,
+		"org_id": 3,
+		"person_id": 2,
+		"active_flag": true,
+		"note": null,
+		"owner_name": "Chris Marshall"
+	}
+}`
+
+const activitiesResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 4,
+			"company_id": 2178381,
+			"user_id": 3219426,
+			"done": true,
+			"type": "call",
+			"due_date": "2017-11-16",
+			"due_time": "",
+			"duration": "",
+			"add_time": "2017-11-16 20:03:54",
+			"marked_as_done_time": "2017-11-16 21:00:00",
+			"subject": "Call",
+			"deal_id": 1,
+			"org_id": 1,
+			"person_id": 1,
+			"active_flag": true,
+			"note": ""
+		},
+		{
+			"id": 5,
+			"company_id": 2178381,
+			"user_id": 3219426,
+			"done": false,
+			"type": "meeting",
+			"due_date": "2017-11-20",
+			"due_time": "14:00",
+			"duration": "01:00",
+			"add_time": "2017-11-17 09:12:01",
+			"marked_as_done_time": "",
+			"subject": "Demo",
+			"deal_id": 1,
+			"org_id": 1,
+			"person_id": 1,
+			"active_flag": true,
+			"note": "Bring the pricing sheet"
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 2,
+			"more_items_in_collection": true,
+			"next_start": 2
+		}
+	}
+}`
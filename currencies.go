@@ -0,0 +1,32 @@
+package pipedrive
+
+import (
+	"strconv"
+)
+
+// Currency is a PipeDrive Currency representation
+type Currency struct {
+	Code          string `json:"code"`
+	Symbol        string `json:"symbol"`
+	DecimalPoints int    `json:"decimal_points"`
+	Name          string `json:"name"`
+}
+
+// ListCurrencies returns all supported Currencies
+func (c *Client) ListCurrencies() ([]Currency, error) {
+	var currencies []Currency
+	_, err := c.getEntity("/currencies", &currencies)
+	return currencies, err
+}
+
+// FormattedValue formats the Deal's value with the symbol and decimal points
+// of its currency, e.g. "$1500.50". A currency missing from currencies leaves
+// the value unformatted.
+func (d *Deal) FormattedValue(currencies []Currency) string {
+	for _, currency := range currencies {
+		if currency.Code == d.Currency {
+			return currency.Symbol + strconv.FormatFloat(d.Value, 'f', currency.DecimalPoints, 64)
+		}
+	}
+	return strconv.FormatFloat(d.Value, 'f', -1, 64)
+}
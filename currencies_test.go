@@ -0,0 +1,91 @@
+package pipedrive
+
+import (
+	"testing"
+)
+
+func Test_ListCurrencies(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/currencies?api_token=abc123": currenciesResp,
+			},
+		},
+	})
+
+	currencies, err := client.ListCurrencies()
+	if err != nil {
+		t.Errorf("Unexpected error listing currencies: %+v", err)
+		return
+	}
+
+	expected := []Currency{
+		{Code: "USD", Symbol: "$", DecimalPoints: 2, Name: "US Dollar"},
+		{Code: "JPY", Symbol: "¥", DecimalPoints: 0, Name: "Japanese Yen"},
+	}
+	if len(currencies) != len(expected) {
+		t.Errorf("Expected %d currencies; got %d", len(expected), len(currencies))
+		return
+	}
+	for i := range expected {
+		if currencies[i] != expected[i] {
+			t.Errorf("Currency want %+v; got %+v", expected[i], currencies[i])
+		}
+	}
+}
+
+func Test_ListCurrencies_NullData(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/currencies?api_token=abc123": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	currencies, err := client.ListCurrencies()
+	if err != nil || len(currencies) != 0 {
+		t.Errorf("Expected no currencies; got %d (%+v)", len(currencies), err)
+	}
+}
+
+const currenciesResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"code": "USD",
+			"name": "US Dollar",
+			"decimal_points": 2,
+			"symbol": "$",
+			"active_flag": true,
+			"is_custom_flag": false
+		},
+		{
+			"id": 2,
+			"code": "JPY",
+			"name": "Japanese Yen",
+			"decimal_points": 0,
+			"symbol": "¥",
+			"active_flag": true,
+			"is_custom_flag": false
+		}
+	]
+}`
+
+func Test_FormattedValue(t *testing.T) {
+	currencies := []Currency{
+		{Code: "USD", Symbol: "$", DecimalPoints: 2, Name: "US Dollar"},
+		{Code: "JPY", Symbol: "¥", DecimalPoints: 0, Name: "Japanese Yen"},
+	}
+	cases := map[string]Deal{
+		"$1500.50": {Value: 1500.5, Currency: "USD"},
+		"¥1500":    {Value: 1499.6, Currency: "JPY"},
+		"1250.5":   {Value: 1250.5, Currency: "EUR"},
+	}
+	for expected, deal := range cases {
+		if actual := deal.FormattedValue(currencies); actual != expected {
+			t.Errorf("Formatted value want %s; got %s", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,637 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetDeal returns the Deal with the given ID, or ErrNotFound
+func (c *Client) GetDeal(dealID int) (*Deal, error) {
+	if dealID < 1 {
+		return nil, errors.New("Deal ID must be positive")
+	}
+
+	var d *Deal
+	if _, err := c.getEntity(fmt.Sprintf("/deals/%d", dealID), &d); err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrNotFound
+	}
+	return d, nil
+}
+
+// SearchDealsByField returns the Deals whose field with the given key has
+// exactly the given value, e.g. an external ID kept in a custom field. With
+// ClientOptions.ResolveFieldNames set, fieldKey may also be a field name.
+func (c *Client) SearchDealsByField(fieldKey, value string) ([]Deal, error) {
+	if fieldKey == "" || value == "" {
+		return nil, errors.New("Must have a field key and value")
+	}
+	if c.ResolveFieldNames {
+		var err error
+		if fieldKey, err = c.resolveFieldKey("deal", fieldKey); err != nil {
+			return nil, err
+		}
+	}
+
+	query := url.Values{}
+	query.Set("term", value)
+	query.Set("field_type", "dealField")
+	query.Set("field_key", fieldKey)
+	query.Set("exact_match", "true")
+	query.Set("return_item_ids", "true")
+	var ids []int
+	err := c.getAllPages("/itemSearch/field?"+query.Encode(), func(data json.RawMessage) error {
+		var page []struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, item := range page {
+			ids = append(ids, item.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The search only returns IDs, so the Deals are fetched one by one
+	var deals []Deal
+	for _, id := range ids {
+		d, err := c.GetDeal(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		deals = append(deals, *d)
+	}
+	return deals, nil
+}
+
+// GetPersonDeals returns the Deals of a Person with the given status: "open",
+// "won", "lost", "deleted" or "all_not_deleted". An empty status returns all
+// Deals that aren't deleted.
+func (c *Client) GetPersonDeals(personID int, status string) ([]Deal, error) {
+	if personID < 1 {
+		return nil, errors.New("Person ID must be positive")
+	}
+
+	path := fmt.Sprintf("/persons/%d/deals", personID)
+	switch status {
+	case "":
+	case "open", "won", "lost", "deleted", "all_not_deleted":
+		path += "?status=" + status
+	default:
+		return nil, fmt.Errorf("Invalid deal status %q", status)
+	}
+
+	var deals []Deal
+	err := c.getAllPages(path, func(data json.RawMessage) error {
+		var page []Deal
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		deals = append(deals, page...)
+		return nil
+	})
+	return deals, err
+}
+
+// ListDealParticipants returns the Persons participating in a Deal
+func (c *Client) ListDealParticipants(dealID int) ([]Person, error) {
+	if dealID < 1 {
+		return nil, errors.New("Deal ID must be positive")
+	}
+
+	var persons []Person
+	err := c.getAllPages(fmt.Sprintf("/deals/%d/participants", dealID), func(data json.RawMessage) error {
+		var page []struct {
+			Person Person `json:"person"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, participant := range page {
+			persons = append(persons, participant.Person)
+		}
+		return nil
+	})
+	return persons, err
+}
+
+// DealQuery builds the filters for QueryDeals. The zero value matches every
+// Deal not deleted, e.g. DealQuery{}.Stage(3).Status("open").Limit(50).
+type DealQuery struct {
+	stageID  int
+	ownerID  int
+	filterID int
+	status   string
+	start    int
+	limit    int
+}
+
+// Stage only matches Deals in the given stage
+func (q DealQuery) Stage(stageID int) DealQuery {
+	q.stageID = stageID
+	return q
+}
+
+// Owner only matches Deals owned by the given user
+func (q DealQuery) Owner(userID int) DealQuery {
+	q.ownerID = userID
+	return q
+}
+
+// Filter only matches Deals matching the given saved Filter
+func (q DealQuery) Filter(filterID int) DealQuery {
+	q.filterID = filterID
+	return q
+}
+
+// Status only matches Deals with the given status
+func (q DealQuery) Status(status string) DealQuery {
+	q.status = status
+	return q
+}
+
+// Start skips the first start Deals
+func (q DealQuery) Start(start int) DealQuery {
+	q.start = start
+	return q
+}
+
+// Limit caps the number of Deals returned
+func (q DealQuery) Limit(limit int) DealQuery {
+	q.limit = limit
+	return q
+}
+
+// Encode returns the query string of q
+func (q DealQuery) Encode() string {
+	values := url.Values{}
+	if q.stageID != 0 {
+		values.Set("stage_id", strconv.Itoa(q.stageID))
+	}
+	if q.ownerID != 0 {
+		values.Set("user_id", strconv.Itoa(q.ownerID))
+	}
+	if q.filterID != 0 {
+		values.Set("filter_id", strconv.Itoa(q.filterID))
+	}
+	if q.status != "" {
+		values.Set("status", q.status)
+	}
+	if q.start != 0 {
+		values.Set("start", strconv.Itoa(q.start))
+	}
+	if q.limit != 0 {
+		values.Set("limit", strconv.Itoa(q.limit))
+	}
+	return values.Encode()
+}
+
+// QueryDeals returns the Deals matching q. The returned Pagination tells where
+// the next page starts, e.g. for q.Start(pagination.NextStart).
+func (c *Client) QueryDeals(q DealQuery) ([]Deal, Pagination, error) {
+	path := "/deals"
+	if query := q.Encode(); query != "" {
+		path += "?" + query
+	}
+
+	var deals []Deal
+	resp, err := c.getEntity(path, &deals)
+	return deals, resp.AdditionalData.Pagination, err
+}
+
+// ListDealsWithParams returns the Deals matching any of the /deals query
+// parameters, e.g. "sort" or "owned_by_you". api_token is added automatically
+// and ignored when set in params.
+func (c *Client) ListDealsWithParams(params url.Values) ([]Deal, Pagination, error) {
+	query := url.Values{}
+	for name, values := range params {
+		if name != "api_token" {
+			query[name] = values
+		}
+	}
+
+	path := "/deals"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var deals []Deal
+	resp, err := c.getEntity(path, &deals)
+	return deals, resp.AdditionalData.Pagination, err
+}
+
+// ListDealsByFilter returns a page of the Deals matching a saved Filter,
+// starting at start. A limit of 0 uses the API's default page size.
+func (c *Client) ListDealsByFilter(filterID, start, limit int) ([]Deal, Pagination, error) {
+	if filterID < 1 {
+		return nil, Pagination{}, errors.New("Filter ID must be positive")
+	}
+
+	return c.QueryDeals(DealQuery{}.Filter(filterID).Start(start).Limit(limit))
+}
+
+// DealSummary is the total count and value of a set of Deals. TotalValue is
+// converted to the company's default currency.
+type DealSummary struct {
+	TotalCount       int
+	TotalValue       float64
+	ValuesByCurrency map[string]float64
+}
+
+// DealsSummary returns the totals of the Deals in a stage, or of every Deal
+// when stageID is 0
+func (c *Client) DealsSummary(stageID int) (*DealSummary, error) {
+	query := url.Values{}
+	if stageID != 0 {
+		query.Set("stage_id", strconv.Itoa(stageID))
+	}
+	return c.dealsSummary(query)
+}
+
+// DealsSummaryByOwner returns the totals of the Deals of each active User,
+// keyed by User ID. PipeDrive can't group summaries, so this fetches one per
+// User.
+func (c *Client) DealsSummaryByOwner() (map[int]DealSummary, error) {
+	users, err := c.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := map[int]DealSummary{}
+	for _, user := range users {
+		if !user.Active {
+			continue
+		}
+		summary, err := c.dealsSummary(url.Values{"user_id": {strconv.Itoa(user.ID)}})
+		if err != nil {
+			return nil, err
+		}
+		summaries[user.ID] = *summary
+	}
+	return summaries, nil
+}
+
+func (c *Client) dealsSummary(query url.Values) (*DealSummary, error) {
+	path := "/deals/summary"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var data struct {
+		TotalCount  int             `json:"total_count"`
+		TotalValue  float64         `json:"total_currency_converted_value"`
+		ValuesTotal json.RawMessage `json:"values_total"`
+	}
+	if _, err := c.getEntity(path, &data); err != nil {
+		return nil, err
+	}
+
+	// The totals per currency are an empty list rather than an object when
+	// there are no Deals
+	var valuesTotal map[string]struct {
+		Value float64 `json:"value"`
+	}
+	if strings.HasPrefix(string(data.ValuesTotal), "{") {
+		if err := json.Unmarshal(data.ValuesTotal, &valuesTotal); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &DealSummary{
+		TotalCount:       data.TotalCount,
+		TotalValue:       data.TotalValue,
+		ValuesByCurrency: map[string]float64{},
+	}
+	for currency, total := range valuesTotal {
+		summary.ValuesByCurrency[currency] = total.Value
+	}
+	return summary, nil
+}
+
+// ConversionReport is how Deals of a pipeline moved through its stages over a
+// period. Rates are percentages.
+type ConversionReport struct {
+	StageConversions []StageConversion `json:"stage_conversions"`
+	WonConversion    float64           `json:"won_conversion"`
+	LostConversion   float64           `json:"lost_conversion"`
+}
+
+// StageConversion is the share of Deals that moved from one stage to the next
+type StageConversion struct {
+	FromStageID    int     `json:"from_stage_id"`
+	ToStageID      int     `json:"to_stage_id"`
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+// GetDealConversionRates returns the stage-to-stage conversion rates of a
+// pipeline's Deals between start and end, formatted as YYYY-MM-DD
+func (c *Client) GetDealConversionRates(pipelineID int, start, end string) (*ConversionReport, error) {
+	if pipelineID < 1 {
+		return nil, errors.New("Pipeline ID must be positive")
+	}
+	for _, date := range []string{start, end} {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("Conversion report dates must be YYYY-MM-DD; got %q", date)
+		}
+	}
+
+	query := url.Values{"start_date": {start}, "end_date": {end}}
+	var report ConversionReport
+	_, err := c.getEntity(fmt.Sprintf("/pipelines/%d/conversion_statistics?%s", pipelineID, query.Encode()), &report)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// SaveDeal updates an existing Deal with its non-zero fields, along with the
+// fields named by their JSON key in zeroFields, e.g. "value", to set them even
+// when zero. Custom Fields and the ExternalID are sent like CreateDeal sends
+// them.
+func (c *Client) SaveDeal(d *Deal, zeroFields ...string) error {
+	if d.ID < 1 {
+		return errors.New("Deal ID must be positive")
+	}
+	if d.Status != "" {
+		if err := d.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	bodyData := changedFields(*d, zeroFields)
+	// PipeDrive sets these itself
+	delete(bodyData, "id")
+	delete(bodyData, "update_time")
+	delete(bodyData, "fields")
+
+	fields, err := c.dealCustomFields(d)
+	if err != nil {
+		return err
+	}
+	for name, value := range fields {
+		bodyData[name] = value
+	}
+	if len(bodyData) == 0 {
+		return errors.New("Deal has no fields to save")
+	}
+
+	return c.UpdateDeal(d.ID, bodyData)
+}
+
+// changedFields returns the fields of the struct v keyed by their JSON names,
+// leaving out the zero ones unless named in zeroFields
+func changedFields(v interface{}, zeroFields []string) map[string]interface{} {
+	keep := map[string]bool{}
+	for _, name := range zeroFields {
+		keep[name] = true
+	}
+
+	fields := map[string]interface{}{}
+	value := reflect.ValueOf(v)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if value.Field(i).IsZero() && !keep[name] {
+			continue
+		}
+		fields[name] = value.Field(i).Interface()
+	}
+	return fields
+}
+
+// DealMatcher reports whether an existing Deal is the same as a wanted one
+type DealMatcher func(existing, wanted Deal) bool
+
+// MatchDealTitleAndPerson considers Deals equal when they share a title and
+// person
+func MatchDealTitleAndPerson(existing, wanted Deal) bool {
+	return existing.Title == wanted.Title && existing.PersonID == wanted.PersonID
+}
+
+// dealSearchItem is a Deal as returned by /deals/search
+type dealSearchItem struct {
+	ID     int        `json:"id"`
+	Title  string     `json:"title"`
+	Value  float64    `json:"value"`
+	Status DealStatus `json:"status"`
+	Owner  struct {
+		ID int `json:"id"`
+	} `json:"owner"`
+	Stage struct {
+		ID int `json:"id"`
+	} `json:"stage"`
+	Person struct {
+		ID int `json:"id"`
+	} `json:"person"`
+	Organization struct {
+		ID int `json:"id"`
+	} `json:"organization"`
+}
+
+func (i dealSearchItem) deal() Deal {
+	return Deal{
+		ID:             i.ID,
+		Title:          i.Title,
+		Value:          i.Value,
+		UserID:         i.Owner.ID,
+		PersonID:       i.Person.ID,
+		OrganizationID: i.Organization.ID,
+		StageID:        i.Stage.ID,
+		Status:         i.Status,
+	}
+}
+
+// FindOrCreateDeal searches for an open Deal with the same title and person
+// and creates a new one if it doesn't exist
+func (c *Client) FindOrCreateDeal(d *Deal) error {
+	return c.FindOrCreateDealMatching(d, MatchDealTitleAndPerson)
+}
+
+// FindOrCreateDealMatching searches for an open Deal considered equal by match
+// and creates a new one if none is found
+func (c *Client) FindOrCreateDealMatching(d *Deal, match DealMatcher) error {
+	if d.Title == "" {
+		return errors.New("Must have a title to find a deal")
+	}
+
+	query := url.Values{}
+	query.Set("term", d.Title)
+	query.Set("fields", "title")
+	query.Set("status", "open")
+	if d.PersonID != 0 {
+		query.Set("person_id", strconv.Itoa(d.PersonID))
+	}
+	if d.OrganizationID != 0 {
+		query.Set("organization_id", strconv.Itoa(d.OrganizationID))
+	}
+
+	var found *Deal
+	err := c.getAllPages("/deals/search?"+query.Encode(), func(data json.RawMessage) error {
+		var page struct {
+			Items []struct {
+				Item dealSearchItem `json:"item"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, result := range page.Items {
+			existing := result.Item.deal()
+			if found == nil && result.Item.Status == DealStatusOpen && match(existing, *d) {
+				found = &existing
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if found != nil {
+		d.ID = found.ID
+		return nil
+	}
+
+	return c.CreateDeal(d)
+}
+
+// FindOrCreateDealByExternalID returns the Deal already created with the same
+// ExternalID into d, or creates it when there is none. This makes creating
+// Deals safe to repeat, e.g. when handling events delivered more than once.
+func (c *Client) FindOrCreateDealByExternalID(d *Deal) error {
+	if d.ExternalID == "" {
+		return errors.New("Must have an external ID to find a deal")
+	}
+	if c.ExternalIDField == "" {
+		return errors.New("ExternalIDField must be set to find a deal by external ID")
+	}
+
+	deals, err := c.SearchDealsByField(c.ExternalIDField, d.ExternalID)
+	if err != nil {
+		return err
+	}
+	if len(deals) > 0 {
+		externalID := d.ExternalID
+		*d = deals[0]
+		d.ExternalID = externalID
+		return nil
+	}
+
+	return c.CreateDeal(d)
+}
+
+// CreatePersonWithDeal finds or creates the Person and then creates the Deal
+// for them. Errors name the step that failed. The Deal is checked before the
+// Person is touched, and when it still can't be created, a Person created by
+// this call is deleted again, while a Person that was found is kept. An
+// Organization created for the Person's OrganizationName is kept either way. A
+// dry run that would create the Person doesn't create the Deal either.
+func (c *Client) CreatePersonWithDeal(p *Person, d *Deal) error {
+	if d.Title == "" {
+		return errors.New("Deal requires a title")
+	}
+	if _, err := c.dealCustomFields(d); err != nil {
+		return err
+	}
+
+	created, err := c.FindOrCreatePersonEx(p)
+	if err != nil {
+		return fmt.Errorf("Error finding or creating person: %w", err)
+	}
+
+	// A dry run creates the Person without an ID for the Deal to refer to
+	if c.DryRun && p.ID == 0 {
+		c.logf("Dry run, not creating deal %q for the new person", d.Title)
+		return nil
+	}
+
+	d.PersonID = p.ID
+	if d.OrganizationID == 0 {
+		d.OrganizationID = p.OrganizationID
+	}
+	if err = c.CreateDeal(d); err != nil {
+		err = fmt.Errorf("Error creating deal for person %d: %w", p.ID, err)
+		if !created || p.ID == 0 {
+			return err
+		}
+		if deleteErr := c.DeletePerson(p.ID); deleteErr != nil {
+			return fmt.Errorf("%v; error deleting created person: %w", err, deleteErr)
+		}
+		p.ID = 0
+		d.PersonID = 0
+		return err
+	}
+
+	return nil
+}
+
+// DeleteDeal deletes an existing Deal
+func (c *Client) DeleteDeal(dealID int) error {
+	if dealID < 1 {
+		return errors.New("Deal ID must be positive")
+	}
+
+	return c.delete(fmt.Sprintf("/deals/%d", dealID), nil)
+}
+
+// DeleteDeals deletes the given Deals, using a single bulk request when
+// possible and deleting Deals one by one otherwise. The returned map holds an
+// error for every Deal that couldn't be deleted and is empty on success.
+func (c *Client) DeleteDeals(ids []int) map[int]error {
+	errs := map[int]error{}
+	var pending []int
+	var idStrings []string
+	for _, id := range ids {
+		if id < 1 {
+			errs[id] = errors.New("Deal ID must be positive")
+			continue
+		}
+		pending = append(pending, id)
+		idStrings = append(idStrings, strconv.Itoa(id))
+	}
+	if len(pending) == 0 {
+		return errs
+	}
+
+	var bulk struct {
+		ID []int `json:"id"`
+	}
+	if err := c.delete("/deals?ids="+strings.Join(idStrings, ","), &bulk); err == nil {
+		deleted := map[int]bool{}
+		for _, id := range bulk.ID {
+			deleted[id] = true
+		}
+		var missed []int
+		for _, id := range pending {
+			if !deleted[id] {
+				missed = append(missed, id)
+			}
+		}
+		pending = missed
+	}
+
+	for _, id := range pending {
+		if err := c.DeleteDeal(id); err != nil {
+			errs[id] = err
+		}
+	}
+	return errs
+}
@@ -0,0 +1,817 @@
+package pipedrive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_FindOrCreateDeal_Found(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/search?api_token=abc123&fields=title&person_id=1&start=0&status=open&term=Close+this+deal%21": fmt.Sprintf(dealSearchResp, 5, "Close this deal!", 1),
+			},
+		},
+	})
+
+	deal := Deal{Title: "Close this deal!", PersonID: 1}
+	err := client.FindOrCreateDeal(&deal)
+	if err != nil {
+		t.Errorf("Unexpected error finding deal: %+v", err)
+		return
+	}
+
+	if deal.ID != 5 {
+		t.Errorf("Failed to find deal. Expected ID to be 5; got %d", deal.ID)
+	}
+}
+
+func Test_FindOrCreateDeal_NotMatched(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/search?api_token=abc123&fields=title&person_id=1&start=0&status=open&term=Close+this+deal%21": fmt.Sprintf(dealSearchResp, 5, "Close this deal! (renewal)", 1),
+				"http://base/v1/deals?api_token=abc123": fmt.Sprintf(dealUpdateResp, 6, 1),
+			},
+		},
+	})
+
+	deal := Deal{Title: "Close this deal!", PersonID: 1}
+	err := client.FindOrCreateDeal(&deal)
+	if err != nil {
+		t.Errorf("Unexpected error finding or creating deal: %+v", err)
+		return
+	}
+
+	if deal.ID != 6 {
+		t.Errorf("Failed to create deal. Expected ID to be 6; got %d", deal.ID)
+	}
+}
+
+func Test_CreatePersonWithDeal(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": fmt.Sprintf(personFindResp, 4, "test@videofruit.com"),
+				"http://base/v1/deals?api_token=abc123":                                                     fmt.Sprintf(dealUpdateResp, 6, 1),
+			},
+		},
+	})
+
+	person := Person{Email: []string{"test@videofruit.com"}, OrganizationID: 2}
+	deal := Deal{Title: "Close this deal!"}
+	err := client.CreatePersonWithDeal(&person, &deal)
+	if err != nil {
+		t.Errorf("Unexpected error creating person with deal: %+v", err)
+		return
+	}
+
+	if person.ID != 4 || deal.ID != 6 {
+		t.Errorf("Expected person 4 and deal 6; got %d and %d", person.ID, deal.ID)
+	}
+	if deal.PersonID != 4 || deal.OrganizationID != 2 {
+		t.Errorf("Expected deal to be linked to person 4 and org 2; got %+v", deal)
+	}
+}
+
+func Test_CreatePersonWithDeal_DealFails(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": fmt.Sprintf(personFindResp, 4, "test@videofruit.com"),
+				"http://base/v1/deals?api_token=abc123":                                                     `{"success": false, "error": "Bad request"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/deals?api_token=abc123": http.StatusBadRequest,
+			},
+		},
+	})
+
+	person := Person{Email: []string{"test@videofruit.com"}}
+	err := client.CreatePersonWithDeal(&person, &Deal{Title: "Close this deal!"})
+	if err == nil || !strings.HasPrefix(err.Error(), "Error creating deal for person 4") {
+		t.Errorf("Expected deal creation error; got %+v", err)
+	}
+	if person.ID != 4 {
+		t.Errorf("Expected person to be found; got ID %d", person.ID)
+	}
+}
+
+func Test_CreatePersonWithDeal_RollsBackPerson(t *testing.T) {
+	headers := map[string]http.Header{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, 4, "test@videofruit.com"),
+				"http://base/v1/persons/4?api_token=abc123":                                                 `{"success": true, "data": {"id": 4}}`,
+				"http://base/v1/deals?api_token=abc123":                                                     `{"success": false, "error": "Bad request"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/deals?api_token=abc123": http.StatusBadRequest,
+			},
+			headers: headers,
+		},
+	})
+
+	person := Person{Email: []string{"test@videofruit.com"}}
+	err := client.CreatePersonWithDeal(&person, &Deal{Title: "Close this deal!"})
+	if err == nil || !strings.HasPrefix(err.Error(), "Error creating deal for person 4") {
+		t.Errorf("Expected deal creation error; got %+v", err)
+	}
+	if _, ok := headers["http://base/v1/persons/4?api_token=abc123"]; !ok {
+		t.Error("Expected the created person to be deleted")
+	}
+	if person.ID != 0 {
+		t.Errorf("Expected the deleted person's ID to be cleared; got %d", person.ID)
+	}
+}
+
+func Test_CreatePersonWithDeal_InvalidDeal(t *testing.T) {
+	headers := map[string]http.Header{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{headers: headers},
+	})
+
+	person := Person{Email: []string{"test@videofruit.com"}}
+	if err := client.CreatePersonWithDeal(&person, &Deal{}); err == nil {
+		t.Error("Expected error creating a deal with no title")
+	}
+	if err := client.CreatePersonWithDeal(&person, &Deal{Title: "Close this deal!", ExternalID: "E1"}); err == nil {
+		t.Error("Expected error creating a deal with an external ID but no ExternalIDField")
+	}
+	if len(headers) != 0 {
+		t.Errorf("Expected no requests before the deal is checked; got %d", len(headers))
+	}
+}
+
+func Test_GetPersonDeals(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/1/deals?api_token=abc123&start=0&status=open": personDealsResp,
+				"http://base/v1/persons/2/deals?api_token=abc123&start=0":             `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	deals, err := client.GetPersonDeals(1, "open")
+	if err != nil {
+		t.Errorf("Unexpected error fetching person deals: %+v", err)
+		return
+	}
+	if len(deals) != 1 {
+		t.Errorf("Expected 1 deal; got %d", len(deals))
+		return
+	}
+	expected := Deal{
+		ID:             1,
+		Title:          "Close this deal!",
+		Value:          1000.5,
+		Currency:       "EUR",
+		UserID:         3219426,
+		PersonID:       1,
+		OrganizationID: 2,
+		StageID:        3,
+		PipelineID:     1,
+		Status:         "open",
+		CreatedAt:      PipedriveTime{time.Date(2017, 11, 16, 20, 3, 54, 0, time.UTC)},
+		UpdatedAt:      PipedriveTime{time.Date(2017, 11, 17, 9, 12, 1, 0, time.UTC)},
+		Owner:          &User{ID: 3219426, Name: "Chris Marshall", Email: "chris@videofruit.com", Active: true},
+	}
+	if deal := deals[0]; !reflect.DeepEqual(deal, expected) {
+		t.Errorf("Deal want %+v; got %+v", expected, deal)
+	}
+
+	deals, err = client.GetPersonDeals(2, "")
+	if err != nil || len(deals) != 0 {
+		t.Errorf("Expected no deals; got %d (%+v)", len(deals), err)
+	}
+
+	if _, err = client.GetPersonDeals(1, "Won"); err == nil {
+		t.Error("Expected error fetching deals with an invalid status")
+	}
+}
+
+func Test_DeleteDeals_Bulk(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123&ids=1%2C2%2C3": `{"success": true, "data": {"id": [1, 2, 3]}}`,
+			},
+		},
+	})
+
+	errs := client.DeleteDeals([]int{1, 2, 3})
+	if len(errs) != 0 {
+		t.Errorf("Unexpected errors deleting deals: %+v", errs)
+	}
+}
+
+func Test_DeleteDeals_Fallback(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123&ids=1%2C2": `{"success": false, "error": "Deal not found"}`,
+				"http://base/v1/deals/1?api_token=abc123":         `{"success": true, "data": {"id": 1}}`,
+				"http://base/v1/deals/2?api_token=abc123":         `{"success": false, "error": "Deal not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/deals?api_token=abc123&ids=1%2C2": http.StatusNotFound,
+				"http://base/v1/deals/2?api_token=abc123":         http.StatusNotFound,
+			},
+		},
+	})
+
+	errs := client.DeleteDeals([]int{1, 2, 0})
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 errors; got %+v", errs)
+	}
+	if _, ok := errs[1]; ok {
+		t.Errorf("Unexpected error deleting deal 1: %+v", errs[1])
+	}
+	if !errors.Is(errs[2], ErrNotFound) {
+		t.Errorf("Expected deal 2 not to be found; got %+v", errs[2])
+	}
+	if errs[0] == nil {
+		t.Error("Expected error deleting deal with no ID")
+	}
+}
+
+func Test_DealQuery(t *testing.T) {
+	cases := map[string]DealQuery{
+		"": DealQuery{},
+		"limit=50&stage_id=3&status=open&user_id=3219426": DealQuery{}.Stage(3).Owner(3219426).Status("open").Limit(50),
+		"start=100":            DealQuery{}.Start(100),
+		"filter_id=7&start=50": DealQuery{}.Filter(7).Start(50),
+	}
+	for expected, q := range cases {
+		if actual := q.Encode(); actual != expected {
+			t.Errorf("Query want %q; got %q", expected, actual)
+		}
+	}
+}
+
+func Test_QueryDeals(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123&stage_id=3&status=open": personDealsResp,
+			},
+		},
+	})
+
+	deals, pagination, err := client.QueryDeals(DealQuery{}.Stage(3).Status("open"))
+	if err != nil {
+		t.Errorf("Unexpected error querying deals: %+v", err)
+		return
+	}
+
+	if pagination.MoreItems {
+		t.Error("Expected no more deals")
+	}
+	if len(deals) != 1 || deals[0].ID != 1 {
+		t.Errorf("Unexpected deals: %+v", deals)
+	}
+}
+
+func Test_ListDealsByFilter(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123&filter_id=1&limit=50": personDealsResp,
+			},
+		},
+	})
+
+	deals, _, err := client.ListDealsByFilter(1, 0, 50)
+	if err != nil {
+		t.Errorf("Unexpected error listing deals by filter: %+v", err)
+		return
+	}
+	if len(deals) != 1 || deals[0].ID != 1 {
+		t.Errorf("Unexpected deals: %+v", deals)
+	}
+
+	if _, _, err = client.ListDealsByFilter(0, 0, 50); err == nil {
+		t.Error("Expected error listing deals by filter with no ID")
+	}
+}
+
+func Test_SearchDealsByField(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/dealFields?api_token=abc123&start=0": dealFieldsResp,
+				"http://base/v1/itemSearch/field?api_token=abc123&exact_match=true&field_key=5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778&field_type=dealField&return_item_ids=true&start=0&term=ORD-1": `{
+					"success": true,
+					"data": [{"id": 1, "5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778": "ORD-1"}, {"id": 4, "5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778": "ORD-1"}, {"id": 5, "5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778": "ORD-1"}]
+				}`,
+				"http://base/v1/deals/1?api_token=abc123": `{"success": true, "data": {"id": 1, "title": "Close this deal!"}}`,
+				"http://base/v1/deals/4?api_token=abc123": `{"success": true, "data": null}`,
+				"http://base/v1/deals/5?api_token=abc123": `{"success": false, "error": "Deal not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/deals/5?api_token=abc123": http.StatusNotFound,
+			},
+		},
+		ResolveFieldNames: true,
+	})
+
+	deals, err := client.SearchDealsByField("Industry", "ORD-1")
+	if err != nil {
+		t.Errorf("Unexpected error searching deals: %+v", err)
+		return
+	}
+	if len(deals) != 1 || deals[0].ID != 1 || deals[0].Title != "Close this deal!" {
+		t.Errorf("Expected deal 1; got %+v", deals)
+	}
+
+	if _, err = client.SearchDealsByField("Order ID", "ORD-1"); err == nil {
+		t.Error("Expected error searching an unknown field")
+	}
+}
+
+func Test_FindOrCreateDealByExternalID(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/itemSearch/field?api_token=abc123&exact_match=true&field_key=external_id&field_type=dealField&return_item_ids=true&start=0&term=ORD-1": `{"success": true, "data": [{"id": 1, "external_id": "ORD-1"}]}`,
+				"http://base/v1/itemSearch/field?api_token=abc123&exact_match=true&field_key=external_id&field_type=dealField&return_item_ids=true&start=0&term=ORD-2": `{"success": true, "data": []}`,
+				"http://base/v1/deals/1?api_token=abc123": `{"success": true, "data": {"id": 1, "title": "Close this deal!"}}`,
+				"http://base/v1/deals?api_token=abc123":   dealCreateResp,
+			},
+			sent: sent,
+		},
+		ExternalIDField: "external_id",
+	})
+
+	existing := Deal{Title: "Close this deal!", PersonID: 1, ExternalID: "ORD-1"}
+	if err := client.FindOrCreateDealByExternalID(&existing); err != nil {
+		t.Errorf("Unexpected error finding deal: %+v", err)
+		return
+	}
+	if existing.ID != 1 || existing.ExternalID != "ORD-1" {
+		t.Errorf("Expected existing deal 1; got %+v", existing)
+	}
+	if _, ok := sent["http://base/v1/deals?api_token=abc123"]; ok {
+		t.Error("Expected no deal to be created for a known external ID")
+	}
+
+	created := Deal{Title: "Close this deal!", PersonID: 1, ExternalID: "ORD-2"}
+	if err := client.FindOrCreateDealByExternalID(&created); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+		return
+	}
+	if created.ID != 9 {
+		t.Errorf("Expected created deal 9; got %d", created.ID)
+	}
+	if body := sent["http://base/v1/deals?api_token=abc123"]; !strings.Contains(body, `"external_id":"ORD-2"`) {
+		t.Errorf("Expected the external ID to be sent; got %s", body)
+	}
+}
+
+func Test_ListDealsWithParams(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123&owned_by_you=1&sort=title+ASC": `{
+					"success": true,
+					"data": [{"id": 1, "title": "Close this deal!"}],
+					"additional_data": {"pagination": {"start": 0, "limit": 1, "more_items_in_collection": true, "next_start": 1}}
+				}`,
+			},
+		},
+	})
+
+	deals, pagination, err := client.ListDealsWithParams(url.Values{
+		"sort":         {"title ASC"},
+		"owned_by_you": {"1"},
+		"api_token":    {"other"},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error listing deals: %+v", err)
+		return
+	}
+	if len(deals) != 1 || deals[0].ID != 1 {
+		t.Errorf("Expected deal 1; got %+v", deals)
+	}
+	if !pagination.MoreItems || pagination.NextStart != 1 {
+		t.Errorf("Unexpected pagination: %+v", pagination)
+	}
+}
+
+func Test_SaveDeal(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1?api_token=abc123": fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+			sent: sent,
+		},
+	})
+
+	d := Deal{ID: 1, Title: "Renamed", StageID: 3, Fields: map[string]interface{}{"industry": "Retail"}}
+	if err := client.SaveDeal(&d, "value"); err != nil {
+		t.Errorf("Unexpected error saving deal: %+v", err)
+		return
+	}
+
+	expected := `{"industry":"Retail","stage_id":3,"title":"Renamed","value":0}`
+	if actual := sent["http://base/v1/deals/1?api_token=abc123"]; actual != expected {
+		t.Errorf("Deal body want %s; got %s", expected, actual)
+	}
+
+	if err := client.SaveDeal(&Deal{Title: "Renamed"}); err == nil {
+		t.Error("Expected error saving deal with no ID")
+	}
+	if err := client.SaveDeal(&Deal{ID: 1}); err == nil {
+		t.Error("Expected error saving deal with no fields")
+	}
+}
+
+func Test_SaveDeal_ExternalID(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/dealFields?api_token=abc123&start=0": dealFieldsResp,
+				"http://base/v1/deals/1?api_token=abc123":            fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+			sent: sent,
+		},
+		ResolveFieldNames: true,
+		ExternalIDField:   "Industry",
+	})
+
+	if err := client.SaveDeal(&Deal{ID: 1, ExternalID: "E1"}); err != nil {
+		t.Errorf("Unexpected error saving deal: %+v", err)
+		return
+	}
+	expected := `{"5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778":"E1"}`
+	if actual := sent["http://base/v1/deals/1?api_token=abc123"]; actual != expected {
+		t.Errorf("Deal body want %s; got %s", expected, actual)
+	}
+
+	client.ExternalIDField = "Contract Length"
+	if err := client.SaveDeal(&Deal{ID: 1, ExternalID: "E1"}); err == nil {
+		t.Error("Expected error saving an external ID to an unknown field")
+	}
+	client.ExternalIDField = ""
+	if err := client.SaveDeal(&Deal{ID: 1, Title: "Renamed", ExternalID: "E1"}); err == nil {
+		t.Error("Expected error saving an external ID without ExternalIDField")
+	}
+}
+
+func Test_DealsSummary(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/summary?api_token=abc123&stage_id=3": dealsSummaryResp,
+				"http://base/v1/deals/summary?api_token=abc123":            `{"success": true, "data": {"values_total": [], "total_count": 0, "total_currency_converted_value": 0}}`,
+			},
+		},
+	})
+
+	summary, err := client.DealsSummary(3)
+	if err != nil {
+		t.Errorf("Unexpected error fetching deals summary: %+v", err)
+		return
+	}
+
+	expected := &DealSummary{
+		TotalCount:       3,
+		TotalValue:       2650.5,
+		ValuesByCurrency: map[string]float64{"USD": 1500.5, "EUR": 1000},
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Summary want %+v; got %+v", expected, summary)
+	}
+
+	summary, err = client.DealsSummary(0)
+	if err != nil {
+		t.Errorf("Unexpected error fetching empty deals summary: %+v", err)
+		return
+	}
+	if summary.TotalCount != 0 || len(summary.ValuesByCurrency) != 0 {
+		t.Errorf("Expected empty summary; got %+v", summary)
+	}
+}
+
+func Test_ListDealParticipants(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1/participants?api_token=abc123&start=0": dealParticipantsResp,
+				"http://base/v1/deals/1/participants?api_token=abc123&start=1": dealParticipantsPage2Resp,
+				"http://base/v1/deals/2/participants?api_token=abc123&start=0": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	persons, err := client.ListDealParticipants(1)
+	if err != nil {
+		t.Errorf("Unexpected error listing deal participants: %+v", err)
+		return
+	}
+
+	expected := []Person{
+		{ID: 1, OwnerID: 3219426, OrganizationID: 1, Name: "Tester McTest", Email: []string{"test@videofruit.com"}},
+		{ID: 2, OwnerID: 3219426, Name: "Other Person"},
+	}
+	if !reflect.DeepEqual(persons, expected) {
+		t.Errorf("Participants want %+v; got %+v", expected, persons)
+	}
+
+	if persons, err = client.ListDealParticipants(2); err != nil || len(persons) != 0 {
+		t.Errorf("Expected no participants; got %d (%+v)", len(persons), err)
+	}
+	if _, err = client.ListDealParticipants(0); err == nil {
+		t.Error("Expected error listing participants of deal with no ID")
+	}
+}
+
+const dealParticipantsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 11,
+			"person_id": {
+				"name": "Tester McTest",
+				"value": 1
+			},
+			"add_time": "2017-11-16 20:03:54",
+			"active_flag": true,
+			"person": {
+				"id": 1,
+				"owner_id": 3219426,
+				"org_id": 1,
+				"name": "Tester McTest",
+				"email": [
+					{
+						"label": "work",
+						"value": "test@videofruit.com",
+						"primary": true
+					}
+				],
+				"phone": [
+					{
+						"value": "",
+						"primary": true
+					}
+				]
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 1,
+			"more_items_in_collection": true,
+			"next_start": 1
+		}
+	}
+}`
+
+const dealParticipantsPage2Resp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 12,
+			"person_id": {
+				"name": "Other Person",
+				"value": 2
+			},
+			"person": {
+				"id": 2,
+				"owner_id": 3219426,
+				"org_id": null,
+				"name": "Other Person",
+				"email": null,
+				"phone": null
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 1,
+			"limit": 1,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
+func Test_DealsSummaryByOwner(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/users?api_token=abc123": `{"success": true, "data": [
+					{"id": 1, "name": "Chris Marshall", "active_flag": true},
+					{"id": 2, "name": "Former Rep", "active_flag": false},
+					{"id": 3, "name": "New Rep", "active_flag": true}
+				]}`,
+				"http://base/v1/deals/summary?api_token=abc123&user_id=1": dealsSummaryResp,
+				"http://base/v1/deals/summary?api_token=abc123&user_id=3": `{"success": true, "data": {"values_total": [], "total_count": 0, "total_currency_converted_value": 0}}`,
+			},
+		},
+	})
+
+	summaries, err := client.DealsSummaryByOwner()
+	if err != nil {
+		t.Errorf("Unexpected error fetching deals summaries: %+v", err)
+		return
+	}
+
+	if len(summaries) != 2 {
+		t.Errorf("Expected summaries for 2 users; got %+v", summaries)
+	}
+	if summaries[1].TotalCount != 3 || summaries[1].ValuesByCurrency["EUR"] != 1000 {
+		t.Errorf("Unexpected summary for user 1: %+v", summaries[1])
+	}
+	if summary, ok := summaries[3]; !ok || summary.TotalCount != 0 {
+		t.Errorf("Expected empty summary for user 3; got %+v", summary)
+	}
+}
+
+func Test_GetDealConversionRates(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/pipelines/1/conversion_statistics?api_token=abc123&end_date=2017-12-31&start_date=2017-10-01": conversionStatisticsResp,
+			},
+		},
+	})
+
+	report, err := client.GetDealConversionRates(1, "2017-10-01", "2017-12-31")
+	if err != nil {
+		t.Errorf("Unexpected error fetching conversion rates: %+v", err)
+		return
+	}
+
+	expected := &ConversionReport{
+		StageConversions: []StageConversion{
+			{FromStageID: 1, ToStageID: 2, ConversionRate: 50},
+			{FromStageID: 2, ToStageID: 3, ConversionRate: 33.3},
+		},
+		WonConversion:  12.5,
+		LostConversion: 87.5,
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Conversion report want %+v; got %+v", expected, report)
+	}
+
+	if _, err = client.GetDealConversionRates(1, "10/01/2017", "2017-12-31"); err == nil {
+		t.Error("Expected error fetching conversion rates with an invalid date")
+	}
+}
+
+const conversionStatisticsResp = `{
+	"success": true,
+	"data": {
+		"stage_conversions": [
+			{"from_stage_id": 1, "to_stage_id": 2, "conversion_rate": 50},
+			{"from_stage_id": 2, "to_stage_id": 3, "conversion_rate": 33.3}
+		],
+		"won_conversion": 12.5,
+		"lost_conversion": 87.5
+	}
+}`
+
+const dealsSummaryResp = `{
+	"success": true,
+	"data": {
+		"values_total": {
+			"USD": {
+				"value": 1500.5,
+				"count": 2,
+				"value_converted": 1500.5,
+				"value_formatted": "$1,500.50",
+				"value_converted_formatted": "$1,500.50"
+			},
+			"EUR": {
+				"value": 1000,
+				"count": 1,
+				"value_converted": 1150,
+				"value_formatted": "€1,000",
+				"value_converted_formatted": "$1,150"
+			}
+		},
+		"weighted_values_total": {
+			"USD": {
+				"value": 750.25,
+				"count": 2,
+				"value_formatted": "$750.25"
+			}
+		},
+		"total_count": 3,
+		"total_currency_converted_value": 2650.5,
+		"total_weighted_currency_converted_value": 1325.25,
+		"total_currency_converted_value_formatted": "$2,650.50",
+		"total_weighted_currency_converted_value_formatted": "$1,325.25"
+	}
+}`
+
+const personDealsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"creator_user_id": {
+				"id": 3219426,
+				"name": "Chris Marshall",
+				"email": "chris@videofruit.com",
+				"value": 3219426
+			},
+			"user_id": {
+				"id": 3219426,
+				"name": "Chris Marshall",
+				"email": "chris@videofruit.com",
+				"has_pic": true,
+				"active_flag": true,
+				"value": 3219426
+			},
+			"person_id": {
+				"active_flag": true,
+				"name": "Tester McTest",
+				"email": [
+					{
+						"label": "work",
+						"value": "test@videofruit.com",
+						"primary": true
+					}
+				],
+				"value": 1
+			},
+			"org_id": {
+				"name": "Videofruit",
+				"people_count": 1,
+				"owner_id": 3219426,
+				"value": 2
+			},
+			"stage_id": 3,
+			"title": "Close this deal!",
+			"value": 1000.5,
+			"currency": "EUR",
+			"add_time": "2017-11-16 20:03:54",
+			"update_time": "2017-11-17 09:12:01",
+			"status": "open",
+			"pipeline_id": 1
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
+const dealSearchResp = `{
+	"success": true,
+	"data": {
+		"items": [
+			{
+				"result_score": 1.22,
+				"item": {
+					"id": %d,
+					"type": "deal",
+					"title": "%s",
+					"value": 1000,
+					"currency": "USD",
+					"status": "open",
+					"visible_to": 3,
+					"owner": {
+						"id": 3219426
+					},
+					"stage": {
+						"id": 1,
+						"name": "Lead In"
+					},
+					"person": {
+						"id": %d,
+						"name": "Tester McTest"
+					},
+					"organization": null,
+					"custom_fields": [],
+					"notes": []
+				}
+			}
+		]
+	},
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
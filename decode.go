@@ -0,0 +1,57 @@
+package pipedrive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// decodeMap decodes a JSON object keeping numbers as json.Number, so IDs and
+// amounts aren't rounded through float64. Use getInt and getString to read it.
+func decodeMap(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// getInt returns the integer at key of a map decoded by decodeMap, accepting
+// numbers and numeric strings. A missing or null key is 0.
+func getInt(m map[string]interface{}, key string) (int, error) {
+	switch value := m[key].(type) {
+	case nil:
+		return 0, nil
+	case json.Number:
+		i, err := strconv.Atoi(value.String())
+		if err != nil {
+			return 0, fmt.Errorf("Field %q is not an integer: %s", key, value)
+		}
+		return i, nil
+	case string:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("Field %q is not an integer: %q", key, value)
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("Field %q is not an integer: %v", key, value)
+	}
+}
+
+// getString returns the string at key of a map decoded by decodeMap. A missing
+// or null key is "".
+func getString(m map[string]interface{}, key string) (string, error) {
+	switch value := m[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	default:
+		return "", fmt.Errorf("Field %q is not a string: %v", key, value)
+	}
+}
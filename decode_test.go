@@ -0,0 +1,36 @@
+package pipedrive
+
+import (
+	"testing"
+)
+
+func Test_decodeMap(t *testing.T) {
+	m, err := decodeMap([]byte(`{"id": 9007199254740993, "stage_id": "3", "title": "Close this deal!", "value": 10.5, "org_id": null}`))
+	if err != nil {
+		t.Errorf("Unexpected error decoding map: %+v", err)
+		return
+	}
+
+	if id, err := getInt(m, "id"); err != nil || id != 9007199254740993 {
+		t.Errorf("Expected exact ID; got %d (%+v)", id, err)
+	}
+	if stage, err := getInt(m, "stage_id"); err != nil || stage != 3 {
+		t.Errorf("Expected stage 3; got %d (%+v)", stage, err)
+	}
+	if org, err := getInt(m, "org_id"); err != nil || org != 0 {
+		t.Errorf("Expected no organization; got %d (%+v)", org, err)
+	}
+	if title, err := getString(m, "title"); err != nil || title != "Close this deal!" {
+		t.Errorf("Expected title; got %q (%+v)", title, err)
+	}
+
+	if _, err = getInt(m, "value"); err == nil {
+		t.Error("Expected error reading a fraction as an integer")
+	}
+	if _, err = getInt(m, "title"); err == nil {
+		t.Error("Expected error reading text as an integer")
+	}
+	if _, err = getString(m, "id"); err == nil {
+		t.Error("Expected error reading a number as a string")
+	}
+}
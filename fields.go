@@ -0,0 +1,190 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Field is a PipeDrive field definition, such as a custom deal field
+type Field struct {
+	ID        int           `json:"id"`
+	Key       string        `json:"key"`
+	Name      string        `json:"name"`
+	FieldType string        `json:"field_type"`
+	Options   []FieldOption `json:"options"`
+}
+
+// FieldOption is one of the options of an enum or set Field
+type FieldOption struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
+// fieldEntities are the entities with field definitions, in the order
+// ResolveFieldOption searches them
+var fieldEntities = []string{"deal", "person", "organization", "product"}
+
+// fieldCache holds field definitions per entity. A nil cache caches nothing.
+type fieldCache struct {
+	mu     sync.RWMutex
+	fields map[string][]Field
+}
+
+func newFieldCache() *fieldCache {
+	return &fieldCache{fields: map[string][]Field{}}
+}
+
+func (fc *fieldCache) get(entity string) ([]Field, bool) {
+	if fc == nil {
+		return nil, false
+	}
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+
+	fields, ok := fc.fields[entity]
+	return fields, ok
+}
+
+func (fc *fieldCache) set(entity string, fields []Field) {
+	if fc == nil {
+		return
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.fields[entity] = fields
+}
+
+func (fc *fieldCache) clear() {
+	if fc == nil {
+		return
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.fields = map[string][]Field{}
+}
+
+// ListFields returns the field definitions of an entity: "deal", "person",
+// "organization" or "product". Definitions are cached until ClearFieldCache
+// is called.
+func (c *Client) ListFields(entity string) ([]Field, error) {
+	if !isFieldEntity(entity) {
+		return nil, fmt.Errorf("No field definitions for %q", entity)
+	}
+	if fields, ok := c.fieldCache.get(entity); ok {
+		return fields, nil
+	}
+
+	var fields []Field
+	err := c.getAllPages("/"+entity+"Fields", func(data json.RawMessage) error {
+		var page []Field
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		fields = append(fields, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.fieldCache.set(entity, fields)
+	return fields, nil
+}
+
+// ClearFieldCache drops the cached field definitions so they are fetched
+// again, e.g. after custom fields were changed
+func (c *Client) ClearFieldCache() {
+	c.fieldCache.clear()
+}
+
+// ResolveFieldOption returns the ID of the option labelled optionLabel of the
+// enum or set field with the given key, as the API expects option IDs rather
+// than labels for those fields
+func (c *Client) ResolveFieldOption(fieldKey, optionLabel string) (int, error) {
+	for _, entity := range fieldEntities {
+		fields, err := c.ListFields(entity)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, field := range fields {
+			if field.Key != fieldKey {
+				continue
+			}
+			for _, option := range field.Options {
+				if option.Label == optionLabel {
+					return option.ID, nil
+				}
+			}
+			return 0, fmt.Errorf("Field %q has no option %q", field.Name, optionLabel)
+		}
+	}
+
+	return 0, fmt.Errorf("Unknown field %q", fieldKey)
+}
+
+// resolveFieldKeys returns values keyed by field key, translating any keys
+// that are field names of the entity. Keys that are neither are an error
+// rather than being sent as is.
+func (c *Client) resolveFieldKeys(entity string, values map[string]interface{}) (map[string]interface{}, error) {
+	keys, err := c.fieldKeys(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := map[string]interface{}{}
+	for name, value := range values {
+		key, ok := keys[name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown %s field %q", entity, name)
+		}
+		resolved[key] = value
+	}
+	return resolved, nil
+}
+
+// resolveFieldKey returns the key of the entity's field with the given key or
+// name
+func (c *Client) resolveFieldKey(entity, name string) (string, error) {
+	keys, err := c.fieldKeys(entity)
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := keys[name]
+	if !ok {
+		return "", fmt.Errorf("Unknown %s field %q", entity, name)
+	}
+	return key, nil
+}
+
+// fieldKeys maps both the keys and the names of the entity's fields to their
+// keys
+func (c *Client) fieldKeys(entity string) (map[string]string, error) {
+	fields, err := c.ListFields(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]string{}
+	for _, field := range fields {
+		keys[field.Name] = field.Key
+	}
+	// A key wins over a name in case a field is named like another's key
+	for _, field := range fields {
+		keys[field.Key] = field.Key
+	}
+	return keys, nil
+}
+
+func isFieldEntity(entity string) bool {
+	for _, e := range fieldEntities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
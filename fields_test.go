@@ -0,0 +1,172 @@
+package pipedrive
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_ResolveFieldOption(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/dealFields?api_token=abc123&start=0":   dealFieldsResp,
+				"http://base/v1/personFields?api_token=abc123&start=0": personFieldsResp,
+			},
+		},
+	})
+
+	id, err := client.ResolveFieldOption("5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778", "Software")
+	if err != nil {
+		t.Errorf("Unexpected error resolving deal field option: %+v", err)
+	} else if id != 12 {
+		t.Errorf("Expected option ID 12; got %d", id)
+	}
+
+	id, err = client.ResolveFieldOption("9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", "LinkedIn")
+	if err != nil {
+		t.Errorf("Unexpected error resolving person field option: %+v", err)
+	} else if id != 31 {
+		t.Errorf("Expected option ID 31; got %d", id)
+	}
+
+	if _, err = client.ResolveFieldOption("5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778", "Mining"); err == nil {
+		t.Error("Expected error resolving an unknown option")
+	}
+
+	// Definitions are cached, so no further requests are needed
+	client.httpClient = fakeClient{}
+	if id, err = client.ResolveFieldOption("5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778", "Retail"); err != nil || id != 13 {
+		t.Errorf("Expected cached option ID 13; got %d (%+v)", id, err)
+	}
+
+	client.ClearFieldCache()
+	if _, err = client.ResolveFieldOption("5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778", "Retail"); err == nil {
+		t.Error("Expected cleared field definitions to be fetched again")
+	}
+}
+
+func Test_CreateDeal_FieldNames(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/dealFields?api_token=abc123&start=0": dealFieldsResp,
+				"http://base/v1/deals?api_token=abc123":              dealCreateResp,
+			},
+			sent: sent,
+		},
+		ResolveFieldNames: true,
+	})
+
+	deal := Deal{Title: "Close this deal!", PersonID: 1, Fields: map[string]interface{}{
+		"Industry": 12,
+		"title":    "Close this deal!",
+	}}
+	if err := client.CreateDeal(&deal); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+		return
+	}
+
+	body := sent["http://base/v1/deals?api_token=abc123"]
+	if !strings.Contains(body, `"5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778":12`) || strings.Contains(body, "Industry") {
+		t.Errorf("Expected the field name to be sent as its key; got %s", body)
+	}
+	if _, ok := deal.Fields["Industry"]; !ok {
+		t.Error("Expected the deal's fields to be left untouched")
+	}
+
+	deal = Deal{Title: "Close this deal!", PersonID: 1, Fields: map[string]interface{}{"Contract Length": 12}}
+	if err := client.CreateDeal(&deal); err == nil || !strings.Contains(err.Error(), "Contract Length") {
+		t.Errorf("Expected error creating deal with an unknown field; got %+v", err)
+	}
+}
+
+func Test_fieldCache_Concurrent(t *testing.T) {
+	cache := newFieldCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			entity := fieldEntities[i%len(fieldEntities)]
+			for j := 0; j < 100; j++ {
+				if _, ok := cache.get(entity); !ok {
+					cache.set(entity, []Field{{ID: i}})
+				}
+				if j == 50 && i%10 == 0 {
+					cache.clear()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+const dealFieldsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"key": "title",
+			"name": "Title",
+			"field_type": "varchar",
+			"edit_flag": false
+		},
+		{
+			"id": 45,
+			"key": "5b6c8f0a1e2d3c4b5a6978f0e1d2c3b4a5968778",
+			"name": "Industry",
+			"field_type": "enum",
+			"edit_flag": true,
+			"options": [
+				{
+					"id": 12,
+					"label": "Software"
+				},
+				{
+					"id": 13,
+					"label": "Retail"
+				}
+			]
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
+const personFieldsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 61,
+			"key": "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+			"name": "Lead Source",
+			"field_type": "set",
+			"edit_flag": true,
+			"options": [
+				{
+					"id": 30,
+					"label": "Website"
+				},
+				{
+					"id": 31,
+					"label": "LinkedIn"
+				}
+			]
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
@@ -0,0 +1,122 @@
+package pipedrive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// File is a PipeDrive File representation
+type File struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// multipartForm is a request body encoded as multipart/form-data, which send
+// uses for file uploads
+type multipartForm struct {
+	contentType string
+	data        []byte
+}
+
+// newMultipartForm encodes fields and a file read from content into a
+// multipart form, with the file under the "file" part
+func newMultipartForm(fields map[string]string, filename string, content io.Reader) (*multipartForm, error) {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(part, content); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return &multipartForm{contentType: w.FormDataContentType(), data: buf.Bytes()}, nil
+}
+
+// UploadFile attaches a file read from content to a Deal, e.g. a signed
+// contract
+func (c *Client) UploadFile(dealID int, filename string, content io.Reader) (*File, error) {
+	if dealID < 1 {
+		return nil, errors.New("Deal ID must be positive")
+	}
+	if filename == "" {
+		return nil, errors.New("File requires a name")
+	}
+
+	form, err := newMultipartForm(map[string]string{"deal_id": strconv.Itoa(dealID)}, filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err = c.post("/files", form, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DownloadFile returns the content of a File and its name, or ErrNotFound.
+// The caller must close the content. MaxResponseBytes doesn't apply to it.
+func (c *Client) DownloadFile(fileID int) (io.ReadCloser, string, error) {
+	if fileID < 1 {
+		return nil, "", errors.New("File ID must be positive")
+	}
+
+	resp, err := c.do(http.MethodGet, fmt.Sprintf("/files/%d/download", fileID), nil, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, "", ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, err = c.readResponse(resp)
+		return nil, "", err
+	}
+
+	var filename string
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		filename = params["filename"]
+	}
+
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, filename, nil
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, "", err
+	}
+	return gzipBody{Reader: gz, body: resp.Body}, filename, nil
+}
+
+// gzipBody decompresses a response body, closing it along with the
+// decompressor
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (b gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
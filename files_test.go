@@ -0,0 +1,94 @@
+package pipedrive
+
+import (
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_UploadFile(t *testing.T) {
+	sent := map[string]string{}
+	headers := map[string]http.Header{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/files?api_token=abc123": `{"success": true, "data": {"id": 12, "deal_id": 1, "name": "contract.pdf", "file_name": "contract_1.pdf", "url": "https://example.pipedrive.com/v1/files/12/download"}}`,
+			},
+			sent:    sent,
+			headers: headers,
+		},
+	})
+
+	file, err := client.UploadFile(1, "contract.pdf", strings.NewReader("%PDF-1.4"))
+	if err != nil {
+		t.Errorf("Unexpected error uploading file: %+v", err)
+		return
+	}
+	expected := File{ID: 12, Name: "contract.pdf", URL: "https://example.pipedrive.com/v1/files/12/download"}
+	if *file != expected {
+		t.Errorf("File want %+v; got %+v", expected, *file)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(headers["http://base/v1/files?api_token=abc123"].Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Errorf("Expected a multipart body; got %s (%+v)", mediaType, err)
+		return
+	}
+	form, err := multipart.NewReader(strings.NewReader(sent["http://base/v1/files?api_token=abc123"]), params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Errorf("Unexpected error reading the form: %+v", err)
+		return
+	}
+	if dealID := form.Value["deal_id"]; len(dealID) != 1 || dealID[0] != "1" {
+		t.Errorf("Expected deal_id 1; got %v", dealID)
+	}
+	if len(form.File["file"]) != 1 || form.File["file"][0].Filename != "contract.pdf" {
+		t.Errorf("Expected the contract to be uploaded; got %+v", form.File)
+		return
+	}
+	f, _ := form.File["file"][0].Open()
+	if content, _ := ioutil.ReadAll(f); string(content) != "%PDF-1.4" {
+		t.Errorf("Unexpected file content %q", content)
+	}
+
+	if _, err = client.UploadFile(0, "contract.pdf", strings.NewReader("")); err == nil {
+		t.Error("Expected error uploading file to deal with no ID")
+	}
+}
+
+func Test_DownloadFile(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/files/12/download?api_token=abc123": "%PDF-1.4",
+				"http://base/v1/files/13/download?api_token=abc123": `{"success": false, "error": "File not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/files/13/download?api_token=abc123": http.StatusNotFound,
+			},
+			respHeaders: map[string]http.Header{
+				"http://base/v1/files/12/download?api_token=abc123": {"Content-Disposition": {`attachment; filename="contract.pdf"`}},
+			},
+		},
+	})
+
+	content, filename, err := client.DownloadFile(12)
+	if err != nil {
+		t.Errorf("Unexpected error downloading file: %+v", err)
+		return
+	}
+	defer content.Close()
+	if filename != "contract.pdf" {
+		t.Errorf("Expected contract.pdf; got %q", filename)
+	}
+	if b, _ := ioutil.ReadAll(content); string(b) != "%PDF-1.4" {
+		t.Errorf("Unexpected file content %q", b)
+	}
+
+	if _, _, err = client.DownloadFile(13); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound; got %+v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package pipedrive
+
+import (
+	"fmt"
+)
+
+// Filter is a PipeDrive Filter representation, as saved in the UI
+type Filter struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ListFilters returns the Filters of the given type: "deals", "leads", "org",
+// "people", "products" or "activity". An empty type returns all Filters.
+func (c *Client) ListFilters(filterType string) ([]Filter, error) {
+	path := "/filters"
+	switch filterType {
+	case "":
+	case "deals", "leads", "org", "people", "products", "activity":
+		path += "?type=" + filterType
+	default:
+		return nil, fmt.Errorf("Invalid filter type %q", filterType)
+	}
+
+	var filters []Filter
+	_, err := c.getEntity(path, &filters)
+	return filters, err
+}
@@ -0,0 +1,73 @@
+package pipedrive
+
+import (
+	"testing"
+)
+
+func Test_ListFilters(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/filters?api_token=abc123&type=deals":  filtersResp,
+				"http://base/v1/filters?api_token=abc123&type=people": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	filters, err := client.ListFilters("deals")
+	if err != nil {
+		t.Errorf("Unexpected error listing filters: %+v", err)
+		return
+	}
+
+	expected := []Filter{
+		{ID: 1, Name: "Open deals over 10k", Type: "deals"},
+		{ID: 2, Name: "Closing this month", Type: "deals"},
+	}
+	if len(filters) != len(expected) {
+		t.Errorf("Expected %d filters; got %d", len(expected), len(filters))
+		return
+	}
+	for i := range expected {
+		if filters[i] != expected[i] {
+			t.Errorf("Filter want %+v; got %+v", expected[i], filters[i])
+		}
+	}
+
+	if filters, err = client.ListFilters("people"); err != nil || len(filters) != 0 {
+		t.Errorf("Expected no filters; got %d (%+v)", len(filters), err)
+	}
+	if _, err = client.ListFilters("unicorns"); err == nil {
+		t.Error("Expected error listing filters of an invalid type")
+	}
+}
+
+const filtersResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"name": "Open deals over 10k",
+			"active_flag": true,
+			"type": "deals",
+			"temporary_flag": null,
+			"user_id": 3219426,
+			"add_time": "2017-11-01 10:00:00",
+			"update_time": "2017-11-01 10:00:00",
+			"visible_to": "7",
+			"custom_view_id": 1
+		},
+		{
+			"id": 2,
+			"name": "Closing this month",
+			"active_flag": true,
+			"type": "deals",
+			"temporary_flag": null,
+			"user_id": 3219426,
+			"add_time": "2017-11-02 10:00:00",
+			"update_time": "2017-11-02 10:00:00",
+			"visible_to": "1",
+			"custom_view_id": 2
+		}
+	]
+}`
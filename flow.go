@@ -0,0 +1,46 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// FlowEvent is a single entry of a PipeDrive updates timeline, such as a
+// field change, note or activity
+type FlowEvent struct {
+	Object    string                 `json:"object"`
+	Timestamp PipedriveTime          `json:"timestamp"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// GetDealFlow returns the full timeline of changes for a Deal
+func (c *Client) GetDealFlow(dealID int) ([]FlowEvent, error) {
+	if dealID < 1 {
+		return nil, errors.New("Deal ID must be positive")
+	}
+
+	return c.getFlow(fmt.Sprintf("/deals/%d/flow", dealID))
+}
+
+// GetPersonFlow returns the full timeline of changes for a Person
+func (c *Client) GetPersonFlow(personID int) ([]FlowEvent, error) {
+	if personID < 1 {
+		return nil, errors.New("Person ID must be positive")
+	}
+
+	return c.getFlow(fmt.Sprintf("/persons/%d/flow", personID))
+}
+
+func (c *Client) getFlow(path string) ([]FlowEvent, error) {
+	var events []FlowEvent
+	err := c.getAllPages(path, func(data json.RawMessage) error {
+		var page []FlowEvent
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		events = append(events, page...)
+		return nil
+	})
+	return events, err
+}
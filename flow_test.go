@@ -0,0 +1,161 @@
+package pipedrive
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_GetDealFlow(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1/flow?api_token=abc123&start=0": dealFlowResp,
+				"http://base/v1/deals/1/flow?api_token=abc123&start=2": dealFlowLastResp,
+			},
+		},
+	})
+
+	events, err := client.GetDealFlow(1)
+	if err != nil {
+		t.Errorf("Unexpected error fetching deal flow: %+v", err)
+		return
+	}
+
+	if len(events) != 3 {
+		t.Errorf("Expected 3 flow events; got %d", len(events))
+		return
+	}
+	expected := time.Date(2017, 11, 17, 9, 12, 1, 0, time.UTC)
+	if events[0].Object != "dealChange" || !events[0].Timestamp.Equal(expected) {
+		t.Errorf("Unexpected flow event: %+v", events[0])
+	}
+	if events[0].Data["field_key"] != "stage_id" {
+		t.Errorf("Expected flow event data to be decoded; got %+v", events[0].Data)
+	}
+	if events[2].Object != "note" {
+		t.Errorf("Expected last flow event to be a note; got %+v", events[2])
+	}
+}
+
+func Test_GetDealFlow_InvalidID(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{},
+	})
+
+	if _, err := client.GetDealFlow(0); err == nil {
+		t.Error("Expected error fetching flow of deal with no ID")
+	}
+}
+
+func Test_GetPersonFlow(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/1/flow?api_token=abc123&start=0": personFlowResp,
+			},
+		},
+	})
+
+	events, err := client.GetPersonFlow(1)
+	if err != nil {
+		t.Errorf("Unexpected error fetching person flow: %+v", err)
+		return
+	}
+
+	if len(events) != 1 {
+		t.Errorf("Expected 1 flow event; got %d", len(events))
+		return
+	}
+	event := events[0]
+	if event.Object != "personChange" {
+		t.Errorf("Expected a person change; got %+v", event)
+	}
+	if event.Data["field_key"] != "email" || event.Data["user_id"] != float64(3219426) {
+		t.Errorf("Expected who changed which field; got %+v", event.Data)
+	}
+}
+
+const personFlowResp = `{
+	"success": true,
+	"data": [
+		{
+			"object": "personChange",
+			"timestamp": "2017-11-17 10:00:00",
+			"data": {
+				"id": 31,
+				"item_id": 1,
+				"user_id": 3219426,
+				"field_key": "email",
+				"old_value": "test@videofruit.com",
+				"new_value": "tester@videofruit.com",
+				"is_bulk_update_flag": null,
+				"log_time": "2017-11-17 10:00:00",
+				"change_source": "app"
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
+const dealFlowResp = `{
+	"success": true,
+	"data": [
+		{
+			"object": "dealChange",
+			"timestamp": "2017-11-17 09:12:01",
+			"data": {
+				"id": 12,
+				"item_id": 1,
+				"user_id": 3219426,
+				"field_key": "stage_id",
+				"old_value": "1",
+				"new_value": "3",
+				"log_time": "2017-11-17 09:12:01"
+			}
+		},
+		{
+			"object": "activity",
+			"timestamp": "2017-11-16 21:00:00",
+			"data": {
+				"id": 4,
+				"subject": "Call",
+				"done": true
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 2,
+			"more_items_in_collection": true,
+			"next_start": 2
+		}
+	}
+}`
+
+const dealFlowLastResp = `{
+	"success": true,
+	"data": [
+		{
+			"object": "note",
+			"timestamp": "2017-11-16 20:03:54",
+			"data": {
+				"id": 7,
+				"content": "Interested in the annual plan"
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 2,
+			"limit": 2,
+			"more_items_in_collection": false
+		}
+	}
+}`
@@ -0,0 +1,26 @@
+package pipedrive
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AddFollower makes a user follow a Deal, Person or Organization. entity must
+// be one of "deals", "persons" or "organizations".
+func (c *Client) AddFollower(entity string, entityID, userID int) error {
+	switch entity {
+	case "deals", "persons", "organizations":
+	default:
+		return fmt.Errorf("Cannot add followers to %q", entity)
+	}
+	if entityID < 1 {
+		return errors.New("Entity ID must be positive")
+	}
+	if userID < 1 {
+		return errors.New("User ID must be positive")
+	}
+
+	return c.post(fmt.Sprintf("/%s/%d/followers", entity, entityID), map[string]interface{}{
+		"user_id": userID,
+	}, nil)
+}
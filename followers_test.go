@@ -0,0 +1,54 @@
+package pipedrive
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_AddFollower(t *testing.T) {
+	sent := map[string]string{}
+	headers := map[string]http.Header{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1/followers?api_token=abc123": `{"success": true, "data": {"user_id": 3219426, "id": 9, "deal_id": 1, "add_time": "2017-11-17 09:12:01"}}`,
+			},
+			sent:    sent,
+			headers: headers,
+		},
+	})
+
+	if err := client.AddFollower("deals", 1, 3219426); err != nil {
+		t.Errorf("Unexpected error adding follower: %+v", err)
+		return
+	}
+
+	expected := `{"user_id":3219426}`
+	if actual := sent["http://base/v1/deals/1/followers?api_token=abc123"]; actual != expected {
+		t.Errorf("Follower body want %s; got %s", expected, actual)
+	}
+	contentType := headers["http://base/v1/deals/1/followers?api_token=abc123"].Get("Content-Type")
+	if contentType != "application/json" {
+		t.Errorf("Expected a JSON body; got %s", contentType)
+	}
+}
+
+func Test_AddFollower_Invalid(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/1/followers?api_token=abc123": `{"success": false, "error": "User is already following", "data": null}`,
+			},
+		},
+	})
+
+	if err := client.AddFollower("activities", 1, 3219426); err == nil {
+		t.Error("Expected error adding follower to activity")
+	}
+	if err := client.AddFollower("deals", 0, 3219426); err == nil {
+		t.Error("Expected error adding follower to deal with no ID")
+	}
+	if err := client.AddFollower("persons", 1, 3219426); err == nil {
+		t.Error("Expected API error adding follower")
+	}
+}
@@ -0,0 +1,150 @@
+package pipedrive
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long idempotency keys are remembered when
+// ClientOptions.IdempotencyTTL isn't set
+const DefaultIdempotencyTTL = 10 * time.Minute
+
+// idempotencyCache remembers the IDs of recently created entities by a
+// caller-supplied key. PipeDrive has no native idempotency keys, so this only
+// deduplicates calls made through the same Client. A nil cache remembers
+// nothing.
+type idempotencyCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]idempotencyEntry
+	// inflight holds a channel per key being created, closed once it's done
+	inflight map[string]chan struct{}
+}
+
+type idempotencyEntry struct {
+	id      int
+	expires time.Time
+}
+
+// newIdempotencyCache returns a cache remembering keys for ttl, telling the time
+// with now
+func newIdempotencyCache(ttl time.Duration, now func() time.Time) *idempotencyCache {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &idempotencyCache{
+		ttl:      ttl,
+		now:      now,
+		entries:  map[string]idempotencyEntry{},
+		inflight: map[string]chan struct{}{},
+	}
+}
+
+func (ic *idempotencyCache) get(key string) (int, bool) {
+	if ic == nil {
+		return 0, false
+	}
+	ic.mu.RLock()
+	defer ic.mu.RUnlock()
+
+	// Expired entries are pruned by set
+	entry, ok := ic.entries[key]
+	if !ok || !ic.now().Before(entry.expires) {
+		return 0, false
+	}
+	return entry.id, true
+}
+
+func (ic *idempotencyCache) set(key string, id int) {
+	if ic == nil {
+		return
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	ic.setLocked(key, id)
+}
+
+func (ic *idempotencyCache) setLocked(key string, id int) {
+	now := ic.now()
+	for k, entry := range ic.entries {
+		if !now.Before(entry.expires) {
+			delete(ic.entries, k)
+		}
+	}
+	ic.entries[key] = idempotencyEntry{id: id, expires: now.Add(ic.ttl)}
+}
+
+// once returns the ID remembered for key, reporting true, or runs create and
+// remembers the ID it returns. Concurrent calls with the same key wait for the
+// create in flight rather than creating again. Failed creates and zero IDs,
+// e.g. in dry run mode, aren't remembered.
+func (ic *idempotencyCache) once(key string, create func() (int, error)) (int, bool, error) {
+	if ic == nil {
+		id, err := create()
+		return id, false, err
+	}
+
+	var done chan struct{}
+	for {
+		ic.mu.Lock()
+		if entry, ok := ic.entries[key]; ok && ic.now().Before(entry.expires) {
+			ic.mu.Unlock()
+			return entry.id, true, nil
+		}
+		inflight, ok := ic.inflight[key]
+		if !ok {
+			done = make(chan struct{})
+			ic.inflight[key] = done
+			ic.mu.Unlock()
+			break
+		}
+		ic.mu.Unlock()
+		// The create in flight may fail, so check again once it's done
+		<-inflight
+	}
+
+	var id int
+	var err error
+	defer func() {
+		ic.mu.Lock()
+		defer ic.mu.Unlock()
+		delete(ic.inflight, key)
+		if err == nil && id != 0 {
+			ic.setLocked(key, id)
+		}
+		close(done)
+	}()
+
+	id, err = create()
+	return id, false, err
+}
+
+// CreateDealIdempotent creates a new Deal unless one was already created with
+// the same key within the IdempotencyTTL, in which case only the existing ID
+// is set on newDeal. Concurrent calls with the same key create a single Deal.
+func (c *Client) CreateDealIdempotent(key string, newDeal *Deal) error {
+	id, cached, err := c.idempotency.once("deal:"+key, func() (int, error) {
+		err := c.CreateDeal(newDeal)
+		return newDeal.ID, err
+	})
+	if cached {
+		newDeal.ID = id
+	}
+	return err
+}
+
+// FindOrCreatePersonIdempotent finds or creates a Person unless one was already
+// returned for the same key within the IdempotencyTTL, in which case only the
+// existing ID is set on newPerson
+func (c *Client) FindOrCreatePersonIdempotent(key string, newPerson *Person) error {
+	id, cached, err := c.idempotency.once("person:"+key, func() (int, error) {
+		err := c.FindOrCreatePerson(newPerson)
+		return newPerson.ID, err
+	})
+	if cached {
+		newPerson.ID = id
+	}
+	return err
+}
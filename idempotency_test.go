@@ -0,0 +1,157 @@
+package pipedrive
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_CreateDealIdempotent(t *testing.T) {
+	posts := 0
+	clock := &fakeClock{now: time.Date(2017, 11, 17, 9, 0, 0, 0, time.UTC)}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: countingClient{
+			fakeClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/deals?api_token=abc123": fmt.Sprintf(dealUpdateResp, 6, 1),
+				},
+			},
+			posts: &posts,
+		},
+		IdempotencyTTL: time.Minute,
+		Clock:          clock,
+	})
+
+	for i := 0; i < 2; i++ {
+		deal := Deal{Title: "Close this deal!", PersonID: 1}
+		if err := client.CreateDealIdempotent("webhook-1", &deal); err != nil {
+			t.Errorf("Unexpected error creating deal: %+v", err)
+			return
+		}
+		if deal.ID != 6 {
+			t.Errorf("Expected deal ID 6; got %d", deal.ID)
+		}
+	}
+	if posts != 1 {
+		t.Errorf("Expected 1 create request for a repeated key; got %d", posts)
+	}
+
+	if err := client.CreateDealIdempotent("webhook-2", &Deal{Title: "Close this deal!", PersonID: 1}); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+	}
+	clock.Sleep(time.Minute)
+	if err := client.CreateDealIdempotent("webhook-1", &Deal{Title: "Close this deal!", PersonID: 1}); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+	}
+	if posts != 3 {
+		t.Errorf("Expected 3 create requests for a new and an expired key; got %d", posts)
+	}
+}
+
+func Test_CreateDealIdempotent_Concurrent(t *testing.T) {
+	var posts int32
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: slowClient{
+			fakeClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/deals?api_token=abc123": fmt.Sprintf(dealUpdateResp, 6, 1),
+				},
+			},
+			posts: &posts,
+		},
+	})
+
+	var wg sync.WaitGroup
+	ids := make([]int, 10)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			deal := Deal{Title: "Close this deal!", PersonID: 1}
+			if err := client.CreateDealIdempotent("webhook-1", &deal); err != nil {
+				t.Errorf("Unexpected error creating deal: %+v", err)
+			}
+			ids[i] = deal.ID
+		}(i)
+	}
+	wg.Wait()
+
+	if posts != 1 {
+		t.Errorf("Expected 1 create request for concurrent calls; got %d", posts)
+	}
+	for i, id := range ids {
+		if id != 6 {
+			t.Errorf("Expected deal ID 6 for call %d; got %d", i, id)
+		}
+	}
+}
+
+func Test_CreateDealIdempotent_DryRun(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{},
+		DryRun:     true,
+	})
+
+	if err := client.CreateDealIdempotent("webhook-1", &Deal{Title: "Close this deal!", PersonID: 1}); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+	}
+	if _, ok := client.idempotency.get("deal:webhook-1"); ok {
+		t.Error("Expected no ID to be remembered in dry run mode")
+	}
+}
+
+func Test_idempotencyCache_Concurrent(t *testing.T) {
+	cache := newIdempotencyCache(time.Minute, time.Now)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i%5)
+			for j := 0; j < 100; j++ {
+				if _, ok := cache.get(key); !ok {
+					cache.set(key, i)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 5; i++ {
+		if _, ok := cache.get(fmt.Sprintf("key-%d", i)); !ok {
+			t.Errorf("Expected key-%d to be cached", i)
+		}
+	}
+}
+
+// countingClient wraps fakeClient, counting POST requests
+type countingClient struct {
+	fakeClient
+	posts *int
+}
+
+func (c countingClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost {
+		*c.posts++
+	}
+	return c.fakeClient.Do(req)
+}
+
+// slowClient wraps fakeClient, counting POST requests and taking a while to
+// answer them so concurrent callers overlap
+type slowClient struct {
+	fakeClient
+	posts *int32
+}
+
+func (c slowClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost {
+		atomic.AddInt32(c.posts, 1)
+		time.Sleep(10 * time.Millisecond)
+	}
+	return c.fakeClient.Do(req)
+}
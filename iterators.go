@@ -0,0 +1,127 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// pageIterator walks the items of a list endpoint, fetching a page only once
+// the previous one is used up
+type pageIterator struct {
+	client *Client
+	path   string
+	start  int
+	done   bool
+	page   []json.RawMessage
+}
+
+func (c *Client) newPageIterator(path string) (*pageIterator, error) {
+	it := &pageIterator{client: c, path: path}
+	if err := it.fetch(); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// next decodes the next item into out, reporting false when there are no more
+func (it *pageIterator) next(out interface{}) (bool, error) {
+	for len(it.page) == 0 {
+		if it.done {
+			return false, nil
+		}
+		if err := it.fetch(); err != nil {
+			return false, err
+		}
+	}
+
+	item := it.page[0]
+	it.page = it.page[1:]
+	return true, decodeData(item, out)
+}
+
+func (it *pageIterator) fetch() error {
+	it.page = nil
+	resp, err := it.client.getEntity(fmt.Sprintf("%s?start=%d", it.path, it.start), &it.page)
+	if err != nil {
+		return err
+	}
+
+	pagination := resp.AdditionalData.Pagination
+	if !pagination.MoreItems || pagination.NextStart <= it.start {
+		it.done = true
+	}
+	it.start = pagination.NextStart
+	return nil
+}
+
+// PersonIterator iterates over every Person one page at a time
+type PersonIterator struct {
+	pages *pageIterator
+}
+
+// IteratePersons returns an iterator over every Person, holding only one page
+// of them in memory at a time
+func (c *Client) IteratePersons() (*PersonIterator, error) {
+	pages, err := c.newPageIterator("/persons")
+	if err != nil {
+		return nil, err
+	}
+	return &PersonIterator{pages: pages}, nil
+}
+
+// Next returns the next Person, or nil when there are no more
+func (it *PersonIterator) Next() (*Person, error) {
+	var p Person
+	if ok, err := it.pages.next(&p); !ok || err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// DealIterator iterates over every Deal one page at a time
+type DealIterator struct {
+	pages *pageIterator
+}
+
+// IterateDeals returns an iterator over every Deal, holding only one page of
+// them in memory at a time
+func (c *Client) IterateDeals() (*DealIterator, error) {
+	pages, err := c.newPageIterator("/deals")
+	if err != nil {
+		return nil, err
+	}
+	return &DealIterator{pages: pages}, nil
+}
+
+// Next returns the next Deal, or nil when there are no more
+func (it *DealIterator) Next() (*Deal, error) {
+	var d Deal
+	if ok, err := it.pages.next(&d); !ok || err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// OrganizationIterator iterates over every Organization one page at a time
+type OrganizationIterator struct {
+	pages *pageIterator
+}
+
+// IterateOrganizations returns an iterator over every Organization, holding
+// only one page of them in memory at a time
+func (c *Client) IterateOrganizations() (*OrganizationIterator, error) {
+	pages, err := c.newPageIterator("/organizations")
+	if err != nil {
+		return nil, err
+	}
+	return &OrganizationIterator{pages: pages}, nil
+}
+
+// Next returns the next Organization, or nil when there are no more
+func (it *OrganizationIterator) Next() (*Organization, error) {
+	var o Organization
+	if ok, err := it.pages.next(&o); !ok || err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
@@ -0,0 +1,104 @@
+package pipedrive
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_IteratePersons(t *testing.T) {
+	requested := map[string]http.Header{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons?api_token=abc123&start=0": personsPage1Resp,
+				"http://base/v1/persons?api_token=abc123&start=1": personsPage2Resp,
+			},
+			headers: requested,
+		},
+	})
+
+	it, err := client.IteratePersons()
+	if err != nil {
+		t.Errorf("Unexpected error iterating persons: %+v", err)
+		return
+	}
+
+	var ids []int
+	for {
+		p, err := it.Next()
+		if err != nil {
+			t.Errorf("Unexpected error iterating persons: %+v", err)
+			return
+		}
+		if p == nil {
+			break
+		}
+		ids = append(ids, p.ID)
+		if _, ok := requested["http://base/v1/persons?api_token=abc123&start=1"]; ok && p.ID == 1 {
+			t.Error("Expected the second page to be fetched once the first was used up")
+		}
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Expected persons 1 and 2; got %v", ids)
+	}
+}
+
+func Test_IterateDeals_Empty(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123&start=0": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	it, err := client.IterateDeals()
+	if err != nil {
+		t.Errorf("Unexpected error iterating deals: %+v", err)
+		return
+	}
+	if d, err := it.Next(); d != nil || err != nil {
+		t.Errorf("Expected no deals; got %+v (%+v)", d, err)
+	}
+
+	if _, err = client.IterateOrganizations(); err == nil {
+		t.Error("Expected error iterating organizations that failed to load")
+	}
+}
+
+const personsPage1Resp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"name": "Tester McTest",
+			"email": "test@videofruit.com"
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 1,
+			"more_items_in_collection": true,
+			"next_start": 1
+		}
+	}
+}`
+
+const personsPage2Resp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 2,
+			"name": "Other Person",
+			"email": ""
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 1,
+			"limit": 1,
+			"more_items_in_collection": false
+		}
+	}
+}`
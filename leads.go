@@ -0,0 +1,133 @@
+package pipedrive
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DefaultLeadConversionTimeout is how long ConvertLead waits for PipeDrive to
+// finish converting a Lead when LeadConversionTimeout isn't set
+const DefaultLeadConversionTimeout = 30 * time.Second
+
+// leadConversionPollInterval is how often ConvertLead checks on a conversion
+const leadConversionPollInterval = time.Second
+
+// LeadConversionTimeoutError is returned by ConvertLead when PipeDrive hasn't
+// converted the Lead within the LeadConversionTimeout. The conversion may still
+// complete later.
+type LeadConversionTimeoutError struct {
+	LeadID       string
+	ConversionID string
+	Timeout      time.Duration
+}
+
+func (e *LeadConversionTimeoutError) Error() string {
+	return fmt.Sprintf("Timed out after %s converting lead %s to a deal", e.Timeout, e.LeadID)
+}
+
+// Lead is a PipeDrive Lead representation. Unlike other entities, Leads are
+// identified by UUID strings.
+type Lead struct {
+	ID       string     `json:"id"`
+	Title    string     `json:"title"`
+	PersonID int        `json:"person_id"`
+	OrgID    int        `json:"organization_id"`
+	OwnerID  int        `json:"owner_id"`
+	Value    *LeadValue `json:"value"`
+}
+
+// LeadValue is the potential value of a Lead
+type LeadValue struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// CreateLead creates a new Lead from the initialized Lead, which must have a
+// title and a person or organization
+func (c *Client) CreateLead(l *Lead) error {
+	if l.Title == "" {
+		return errors.New("Lead requires a title")
+	}
+	if l.PersonID == 0 && l.OrgID == 0 {
+		return errors.New("Lead requires a person or organization")
+	}
+
+	// PipeDrive rejects zero IDs in Lead bodies rather than ignoring them
+	bodyData := map[string]interface{}{
+		"title": l.Title,
+	}
+	if l.PersonID != 0 {
+		bodyData["person_id"] = l.PersonID
+	}
+	if l.OrgID != 0 {
+		bodyData["organization_id"] = l.OrgID
+	}
+	if l.Value != nil {
+		bodyData["value"] = l.Value
+	}
+	if ownerID := c.ownerID(l.OwnerID); ownerID != 0 {
+		bodyData["owner_id"] = ownerID
+	}
+
+	created := *l
+	if err := c.post("/leads", bodyData, &created); err != nil {
+		return err
+	}
+
+	*l = created
+	return nil
+}
+
+// ConvertLead converts a Lead into a Deal, returning the Deal with its ID set.
+// PipeDrive converts Leads asynchronously, so this polls until the conversion
+// completes or the LeadConversionTimeout passes, returning a
+// *LeadConversionTimeoutError in that case. A dry run returns a Deal without an
+// ID.
+func (c *Client) ConvertLead(leadID string) (*Deal, error) {
+	if leadID == "" {
+		return nil, errors.New("Must have a lead ID")
+	}
+
+	// Lead conversion is only available in version 2 of the API
+	v2 := c.withAPIVersion("api/v2")
+	path := fmt.Sprintf("/leads/%s/convert/deal", url.PathEscape(leadID))
+	var conversion struct {
+		ID string `json:"conversion_id"`
+	}
+	if err := v2.post(path, map[string]interface{}{}, &conversion); err != nil {
+		return nil, err
+	}
+	// A dry run has no conversion to poll
+	if c.DryRun {
+		return &Deal{}, nil
+	}
+
+	timeout := c.LeadConversionTimeout
+	if timeout <= 0 {
+		timeout = DefaultLeadConversionTimeout
+	}
+	deadline := c.now().Add(timeout)
+	statusPath := fmt.Sprintf("/leads/%s/convert/status/%s", url.PathEscape(leadID), url.PathEscape(conversion.ID))
+	for {
+		var status struct {
+			Status string `json:"status"`
+			DealID int    `json:"deal_id"`
+		}
+		if _, err := v2.getEntity(statusPath, &status); err != nil {
+			return nil, err
+		}
+
+		switch status.Status {
+		case "completed":
+			return &Deal{ID: status.DealID}, nil
+		case "failed", "rejected":
+			return nil, fmt.Errorf("Converting lead %s to a deal %s", leadID, status.Status)
+		}
+		if !c.now().Add(leadConversionPollInterval).Before(deadline) {
+			return nil, &LeadConversionTimeoutError{LeadID: leadID, ConversionID: conversion.ID, Timeout: timeout}
+		}
+		c.pause(leadConversionPollInterval)
+	}
+}
@@ -0,0 +1,174 @@
+package pipedrive
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_CreateLead(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/leads?api_token=abc123": leadCreateResp,
+			},
+			sent: sent,
+		},
+		DefaultUserID: 3219426,
+	})
+
+	lead := Lead{Title: "Inbound lead", PersonID: 1, Value: &LeadValue{Amount: 500, Currency: "USD"}}
+	if err := client.CreateLead(&lead); err != nil {
+		t.Errorf("Unexpected error creating lead: %+v", err)
+		return
+	}
+
+	expected := Lead{
+		ID:       "adf21080-0e10-11eb-879b-05d71fb426ec",
+		Title:    "Inbound lead",
+		PersonID: 1,
+		OwnerID:  3219426,
+		Value:    &LeadValue{Amount: 500, Currency: "USD"},
+	}
+	if !reflect.DeepEqual(lead, expected) {
+		t.Errorf("Lead want %+v; got %+v", expected, lead)
+	}
+
+	expectedBody := `{"owner_id":3219426,"person_id":1,"title":"Inbound lead","value":{"amount":500,"currency":"USD"}}`
+	if actual := sent["http://base/v1/leads?api_token=abc123"]; actual != expectedBody {
+		t.Errorf("Create body want %s; got %s", expectedBody, actual)
+	}
+
+	if err := client.CreateLead(&Lead{PersonID: 1}); err == nil {
+		t.Error("Expected error creating lead with no title")
+	}
+	if err := client.CreateLead(&Lead{Title: "Inbound lead"}); err == nil {
+		t.Error("Expected error creating lead with no person or organization")
+	}
+}
+
+const leadCreateResp = `{
+	"success": true,
+	"data": {
+		"id": "adf21080-0e10-11eb-879b-05d71fb426ec",
+		"title": "Inbound lead",
+		"owner_id": 3219426,
+		"creator_id": 3219426,
+		"label_ids": [],
+		"person_id": 1,
+		"organization_id": null,
+		"source_name": "API",
+		"is_archived": false,
+		"was_seen": false,
+		"value": {
+			"amount": 500,
+			"currency": "USD"
+		},
+		"expected_close_date": null,
+		"next_activity_id": null,
+		"add_time": "2020-10-14T11:30:36.551Z",
+		"update_time": "2020-10-14T11:30:36.551Z",
+		"visible_to": "3",
+		"cc_email": "company+1+leadntPaYKA5QRxXkOuT9@pipedrivemail.com"
+	}
+}`
+
+func Test_ConvertLead(t *testing.T) {
+	id := "adf21080-0e10-11eb-879b-05d71fb426ec"
+	clock := &fakeClock{}
+	client := NewClient("https://company.pipedrive.com/api/v1", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"https://company.pipedrive.com/api/v2/leads/" + id + "/convert/deal?api_token=abc123":                                        `{"success": true, "data": {"conversion_id": "5f2b7c1e-3c4d-4e5f-8a9b-0c1d2e3f4a5b"}}`,
+				"https://company.pipedrive.com/api/v2/leads/" + id + "/convert/status/5f2b7c1e-3c4d-4e5f-8a9b-0c1d2e3f4a5b?api_token=abc123": `{"success": true, "data": {"status": "running", "deal_id": null}}`,
+			},
+		},
+		Clock: clock,
+	})
+
+	_, err := client.ConvertLead(id)
+	timeoutErr, ok := err.(*LeadConversionTimeoutError)
+	if !ok {
+		t.Fatalf("Expected lead conversion timeout error; got %+v", err)
+	}
+	if timeoutErr.LeadID != id || timeoutErr.ConversionID != "5f2b7c1e-3c4d-4e5f-8a9b-0c1d2e3f4a5b" || timeoutErr.Timeout != DefaultLeadConversionTimeout {
+		t.Errorf("Unexpected lead conversion timeout error: %+v", timeoutErr)
+	}
+	if expected := int(DefaultLeadConversionTimeout/leadConversionPollInterval) - 1; len(clock.slept) != expected {
+		t.Errorf("Expected %d pauses before timing out; got %d", expected, len(clock.slept))
+	}
+	if _, err := client.ConvertLead(""); err == nil {
+		t.Error("Expected error converting lead with no ID")
+	}
+}
+
+func Test_ConvertLead_Timeout(t *testing.T) {
+	id := "adf21080-0e10-11eb-879b-05d71fb426ec"
+	clock := &fakeClock{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/api/v2/leads/" + id + "/convert/deal?api_token=abc123":            `{"success": true, "data": {"conversion_id": "5f2b7c1e"}}`,
+				"http://base/api/v2/leads/" + id + "/convert/status/5f2b7c1e?api_token=abc123": `{"success": true, "data": {"status": "running", "deal_id": null}}`,
+			},
+		},
+		Clock:                 clock,
+		LeadConversionTimeout: 5 * time.Second,
+	})
+
+	_, err := client.ConvertLead(id)
+	if timeoutErr, ok := err.(*LeadConversionTimeoutError); !ok || timeoutErr.Timeout != 5*time.Second {
+		t.Errorf("Expected 5s lead conversion timeout error; got %+v", err)
+	}
+	if len(clock.slept) != 4 {
+		t.Errorf("Expected 4 pauses before timing out; got %d", len(clock.slept))
+	}
+}
+
+func Test_ConvertLead_DryRun(t *testing.T) {
+	id := "adf21080-0e10-11eb-879b-05d71fb426ec"
+	logger := &recordingLogger{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{},
+		DryRun:     true,
+		Logger:     logger,
+	})
+
+	deal, err := client.ConvertLead(id)
+	if err != nil {
+		t.Errorf("Expected dry run to succeed; got %+v", err)
+		return
+	}
+	if deal == nil || deal.ID != 0 {
+		t.Errorf("Expected a deal without an ID; got %+v", deal)
+	}
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "POST /leads/"+id+"/convert/deal") {
+		t.Errorf("Expected only the conversion to be logged; got %+v", logger.lines)
+	}
+}
+
+func Test_ConvertLead_Completed(t *testing.T) {
+	id := "adf21080-0e10-11eb-879b-05d71fb426ec"
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/api/v2/leads/" + id + "/convert/deal?api_token=abc123":            `{"success": true, "data": {"conversion_id": "5f2b7c1e"}}`,
+				"http://base/api/v2/leads/" + id + "/convert/status/5f2b7c1e?api_token=abc123": `{"success": true, "data": {"status": "completed", "deal_id": 6}}`,
+			},
+		},
+	})
+
+	deal, err := client.ConvertLead(id)
+	if err != nil {
+		t.Errorf("Unexpected error converting lead: %+v", err)
+		return
+	}
+	if deal.ID != 6 {
+		t.Errorf("Expected deal 6; got %d", deal.ID)
+	}
+	if client.APIVersion != "v1" {
+		t.Errorf("Expected client to keep its API version; got %s", client.APIVersion)
+	}
+}
@@ -0,0 +1,23 @@
+package pipedrive
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// LinkMailThread associates a mail thread with a Deal
+func (c *Client) LinkMailThread(threadID, dealID int) error {
+	if threadID < 1 {
+		return errors.New("Mail thread ID must be positive")
+	}
+	if dealID < 1 {
+		return errors.New("Deal ID must be positive")
+	}
+
+	// The mail thread endpoint only takes its parameters form-encoded
+	return c.put(fmt.Sprintf("/mailbox/mailThreads/%d", threadID), url.Values{
+		"deal_id": {strconv.Itoa(dealID)},
+	}, nil)
+}
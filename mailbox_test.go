@@ -0,0 +1,41 @@
+package pipedrive
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_LinkMailThread(t *testing.T) {
+	sent := map[string]string{}
+	headers := map[string]http.Header{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/mailbox/mailThreads/12?api_token=abc123": `{"success": true, "data": {"id": 12, "deal_id": 1, "subject": "Re: pricing"}}`,
+			},
+			sent:    sent,
+			headers: headers,
+		},
+	})
+
+	if err := client.LinkMailThread(12, 1); err != nil {
+		t.Errorf("Unexpected error linking mail thread: %+v", err)
+		return
+	}
+
+	expected := "deal_id=1"
+	if actual := sent["http://base/v1/mailbox/mailThreads/12?api_token=abc123"]; actual != expected {
+		t.Errorf("Link body want %s; got %s", expected, actual)
+	}
+	contentType := headers["http://base/v1/mailbox/mailThreads/12?api_token=abc123"].Get("Content-Type")
+	if contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Expected a form-encoded body; got %s", contentType)
+	}
+
+	if err := client.LinkMailThread(0, 1); err == nil {
+		t.Error("Expected error linking mail thread with no ID")
+	}
+	if err := client.LinkMailThread(12, 0); err == nil {
+		t.Error("Expected error linking mail thread to deal with no ID")
+	}
+}
@@ -0,0 +1,80 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Note is a PipeDrive Note representation
+type Note struct {
+	ID             int           `json:"id"`
+	Content        string        `json:"content"`
+	DealID         int           `json:"deal_id"`
+	PersonID       int           `json:"person_id"`
+	OrganizationID int           `json:"org_id"`
+	UserID         int           `json:"user_id"`
+	User           *NoteUser     `json:"user"`
+	CreatedAt      PipedriveTime `json:"add_time"`
+	UpdatedAt      PipedriveTime `json:"update_time"`
+}
+
+// NoteUser is the author of a Note
+type NoteUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ListNotesForDeal returns all Notes attached to a Deal
+func (c *Client) ListNotesForDeal(dealID int) ([]Note, error) {
+	if dealID < 1 {
+		return nil, errors.New("Deal ID must be positive")
+	}
+
+	return c.listNotes(fmt.Sprintf("/notes?deal_id=%d", dealID))
+}
+
+// ListNotesForPerson returns all Notes attached to a Person
+func (c *Client) ListNotesForPerson(personID int) ([]Note, error) {
+	if personID < 1 {
+		return nil, errors.New("Person ID must be positive")
+	}
+
+	return c.listNotes(fmt.Sprintf("/notes?person_id=%d", personID))
+}
+
+func (c *Client) listNotes(path string) ([]Note, error) {
+	var notes []Note
+	err := c.getAllPages(path, func(data json.RawMessage) error {
+		var page []Note
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		notes = append(notes, page...)
+		return nil
+	})
+	return notes, err
+}
+
+// UpdateNote replaces the content of an existing Note
+func (c *Client) UpdateNote(id int, content string) error {
+	if id < 1 {
+		return errors.New("Note ID must be positive")
+	}
+	if content == "" {
+		return errors.New("Note requires content")
+	}
+
+	return c.put(fmt.Sprintf("/notes/%d", id), map[string]interface{}{
+		"content": content,
+	}, nil)
+}
+
+// DeleteNote deletes an existing Note
+func (c *Client) DeleteNote(id int) error {
+	if id < 1 {
+		return errors.New("Note ID must be positive")
+	}
+
+	return c.delete(fmt.Sprintf("/notes/%d", id), nil)
+}
@@ -0,0 +1,130 @@
+package pipedrive
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_ListNotesForDeal(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/notes?api_token=abc123&deal_id=1&start=0": notesResp,
+			},
+		},
+	})
+
+	notes, err := client.ListNotesForDeal(1)
+	if err != nil {
+		t.Errorf("Unexpected error listing notes: %+v", err)
+		return
+	}
+
+	if len(notes) != 1 {
+		t.Errorf("Expected 1 note; got %d", len(notes))
+		return
+	}
+	note := notes[0]
+	if note.ID != 7 || note.Content != "Interested in the annual plan" || note.DealID != 1 {
+		t.Errorf("Unexpected note: %+v", note)
+	}
+	if note.User == nil || note.User.Email != "chris@videofruit.com" {
+		t.Errorf("Expected note author to be decoded; got %+v", note.User)
+	}
+	if !note.CreatedAt.Equal(time.Date(2017, 11, 16, 20, 3, 54, 0, time.UTC)) {
+		t.Errorf("Unexpected note creation time: %v", note.CreatedAt)
+	}
+}
+
+func Test_UpdateNote(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/notes/7?api_token=abc123": `{"success": true, "data": {"id": 7, "content": "Signed up for the annual plan"}}`,
+			},
+			sent: sent,
+		},
+	})
+
+	if err := client.UpdateNote(7, "Signed up for the annual plan"); err != nil {
+		t.Errorf("Unexpected error updating note: %+v", err)
+	}
+	expected := `{"content":"Signed up for the annual plan"}`
+	if actual := sent["http://base/v1/notes/7?api_token=abc123"]; actual != expected {
+		t.Errorf("Note body want %s; got %s", expected, actual)
+	}
+
+	if err := client.UpdateNote(7, ""); err == nil {
+		t.Error("Expected error updating note with no content")
+	}
+	if err := client.UpdateNote(0, "Content"); err == nil {
+		t.Error("Expected error updating note with no ID")
+	}
+}
+
+func Test_DeleteNote(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/notes/7?api_token=abc123": `{"success": true, "data": true}`,
+			},
+		},
+	})
+
+	if err := client.DeleteNote(7); err != nil {
+		t.Errorf("Unexpected error deleting note: %+v", err)
+	}
+	if err := client.DeleteNote(0); err == nil {
+		t.Error("Expected error deleting note with no ID")
+	}
+}
+
+func Test_ListNotesForPerson_NullData(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/notes?api_token=abc123&person_id=1&start=0": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	notes, err := client.ListNotesForPerson(1)
+	if err != nil {
+		t.Errorf("Unexpected error listing notes: %+v", err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("Expected no notes; got %d", len(notes))
+	}
+}
+
+const notesResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 7,
+			"user_id": 3219426,
+			"deal_id": 1,
+			"person_id": 1,
+			"org_id": 1,
+			"content": "Interested in the annual plan",
+			"add_time": "2017-11-16 20:03:54",
+			"update_time": "2017-11-16 20:03:54",
+			"active_flag": true,
+			"pinned_to_deal_flag": false,
+			"user": {
+				"email": "chris@videofruit.com",
+				"name": "Chris Marshall",
+				"icon_url": null,
+				"is_you": true
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
@@ -0,0 +1,94 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetOrganizationPersons returns all Persons of an Organization
+func (c *Client) GetOrganizationPersons(orgID int) ([]Person, error) {
+	if orgID < 1 {
+		return nil, errors.New("Organization ID must be positive")
+	}
+
+	persons := []Person{}
+	err := c.getAllPages(fmt.Sprintf("/organizations/%d/persons", orgID), func(data json.RawMessage) error {
+		var page []Person
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		persons = append(persons, page...)
+		return nil
+	})
+	return persons, err
+}
+
+// SearchOrganizations returns the Organizations matching term in the given
+// fields: "name", "address", "notes" or "custom_fields". Any other field must
+// be the key of a custom field, or its name with
+// ClientOptions.ResolveFieldNames, but PipeDrive only searches custom fields all
+// together. No fields searches them all. exact only matches whole values.
+func (c *Client) SearchOrganizations(term string, fields []string, exact bool) ([]Organization, error) {
+	if term == "" {
+		return nil, errors.New("Must have a search term")
+	}
+
+	query := url.Values{}
+	query.Set("term", term)
+	if exact {
+		query.Set("exact_match", "true")
+	}
+	var searched []string
+	seen := map[string]bool{}
+	for _, field := range fields {
+		switch field {
+		case "name", "address", "notes", "custom_fields":
+		default:
+			key, err := c.resolveFieldKey("organization", field)
+			if err != nil {
+				return nil, err
+			}
+			if key != field && !c.ResolveFieldNames {
+				return nil, fmt.Errorf("Unknown organization field %q", field)
+			}
+			field = "custom_fields"
+		}
+		if !seen[field] {
+			seen[field] = true
+			searched = append(searched, field)
+		}
+	}
+	if len(searched) > 0 {
+		query.Set("fields", strings.Join(searched, ","))
+	}
+
+	var orgs []Organization
+	err := c.getAllPages("/organizations/search?"+query.Encode(), func(data json.RawMessage) error {
+		var page struct {
+			Items []struct {
+				Item struct {
+					ID    int    `json:"id"`
+					Name  string `json:"name"`
+					Owner struct {
+						ID int `json:"id"`
+					} `json:"owner"`
+				} `json:"item"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, result := range page.Items {
+			orgs = append(orgs, Organization{
+				ID:      result.Item.ID,
+				Name:    result.Item.Name,
+				OwnerID: result.Item.Owner.ID,
+			})
+		}
+		return nil
+	})
+	return orgs, err
+}
@@ -0,0 +1,213 @@
+package pipedrive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_GetOrganizationPersons(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/1/persons?api_token=abc123&start=0": orgPersonsResp,
+				"http://base/v1/organizations/1/persons?api_token=abc123&start=1": orgPersonsLastResp,
+			},
+		},
+	})
+
+	persons, err := client.GetOrganizationPersons(1)
+	if err != nil {
+		t.Errorf("Unexpected error fetching organization persons: %+v", err)
+		return
+	}
+
+	expected := []Person{
+		{
+			ID:             1,
+			OwnerID:        3219426,
+			OrganizationID: 1,
+			Name:           "Tester McTest",
+			Email:          []string{"test@videofruit.com", "tester@example.com"},
+			Phone:          []ContactField{{Value: "555-0100", Label: "work", Primary: true}},
+			Owner:          &User{ID: 3219426, Name: "Chris Marshall", Email: "chris@videofruit.com"},
+		},
+		{
+			ID:             2,
+			OwnerID:        3219426,
+			OrganizationID: 1,
+			Name:           "Other Person",
+			Owner:          &User{ID: 3219426},
+		},
+	}
+	if !reflect.DeepEqual(persons, expected) {
+		t.Errorf("Persons want %+v; got %+v", expected, persons)
+	}
+}
+
+func Test_GetOrganizationPersons_Empty(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/2/persons?api_token=abc123&start=0": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	persons, err := client.GetOrganizationPersons(2)
+	if err != nil {
+		t.Errorf("Unexpected error fetching organization persons: %+v", err)
+	}
+	if persons == nil || len(persons) != 0 {
+		t.Errorf("Expected an empty slice; got %#v", persons)
+	}
+}
+
+const orgPersonsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"company_id": 2178381,
+			"owner_id": {
+				"id": 3219426,
+				"name": "Chris Marshall",
+				"email": "chris@videofruit.com",
+				"value": 3219426
+			},
+			"org_id": {
+				"name": "Videofruit",
+				"people_count": 2,
+				"owner_id": 3219426,
+				"value": 1
+			},
+			"name": "Tester McTest",
+			"phone": [
+				{
+					"label": "work",
+					"value": "555-0100",
+					"primary": true
+				}
+			],
+			"email": [
+				{
+					"label": "work",
+					"value": "test@videofruit.com",
+					"primary": true
+				},
+				{
+					"label": "home",
+					"value": "tester@example.com",
+					"primary": false
+				}
+			]
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 1,
+			"more_items_in_collection": true,
+			"next_start": 1
+		}
+	}
+}`
+
+const orgPersonsLastResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 2,
+			"owner_id": {
+				"id": 3219426,
+				"value": 3219426
+			},
+			"org_id": {
+				"name": "Videofruit",
+				"value": 1
+			},
+			"name": "Other Person",
+			"phone": [
+				{
+					"value": "",
+					"primary": true
+				}
+			],
+			"email": [
+				{
+					"value": "",
+					"primary": true
+				}
+			]
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 1,
+			"limit": 1,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
+func Test_SearchOrganizations(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizationFields?api_token=abc123&start=0": `{"success": true, "data": [
+					{"id": 1, "key": "name", "name": "Name", "field_type": "varchar"},
+					{"id": 2, "key": "9f7e1c2a0b3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f", "name": "Domain", "field_type": "varchar"}
+				]}`,
+				"http://base/v1/organizations/search?api_token=abc123&exact_match=true&fields=name%2Ccustom_fields&start=0&term=videofruit.com": `{
+					"success": true,
+					"data": {
+						"items": [
+							{"result_score": 1, "item": {"id": 2, "type": "organization", "name": "Videofruit", "address": null, "visible_to": 3, "owner": {"id": 3219426}, "custom_fields": ["videofruit.com"], "notes": []}}
+						]
+					},
+					"additional_data": {"pagination": {"start": 0, "limit": 100, "more_items_in_collection": false}}
+				}`,
+			},
+		},
+		ResolveFieldNames: true,
+	})
+
+	orgs, err := client.SearchOrganizations("videofruit.com", []string{"name", "Domain"}, true)
+	if err != nil {
+		t.Errorf("Unexpected error searching organizations: %+v", err)
+		return
+	}
+	if len(orgs) != 1 || orgs[0].ID != 2 || orgs[0].Name != "Videofruit" || orgs[0].OwnerID != 3219426 {
+		t.Errorf("Expected Videofruit; got %+v", orgs)
+	}
+
+	if _, err = client.SearchOrganizations("videofruit.com", []string{"Website"}, true); err == nil {
+		t.Error("Expected error searching an unknown field")
+	}
+}
+
+func Test_SearchOrganizations_FieldKeys(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizationFields?api_token=abc123&start=0": `{"success": true, "data": [
+					{"id": 2, "key": "9f7e1c2a0b3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f", "name": "Domain", "field_type": "varchar"}
+				]}`,
+				"http://base/v1/organizations/search?api_token=abc123&fields=custom_fields&start=0&term=videofruit.com": `{
+					"success": true,
+					"data": {"items": []},
+					"additional_data": {"pagination": {"start": 0, "limit": 100, "more_items_in_collection": false}}
+				}`,
+			},
+		},
+	})
+
+	if _, err := client.SearchOrganizations("videofruit.com", []string{"9f7e1c2a0b3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f"}, false); err != nil {
+		t.Errorf("Unexpected error searching a custom field by key: %+v", err)
+	}
+	if _, err := client.SearchOrganizations("videofruit.com", []string{"Domain"}, false); err == nil {
+		t.Error("Expected error searching a field name without ResolveFieldNames")
+	}
+	if _, err := client.SearchOrganizations("videofruit.com", []string{"website"}, false); err == nil {
+		t.Error("Expected error searching an unknown field")
+	}
+}
@@ -0,0 +1,106 @@
+package pipedrive
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// maxConcurrentRequests bounds the requests batch methods send at once
+const maxConcurrentRequests = 4
+
+// GetPerson returns the Person with the given ID, or ErrNotFound. Naming
+// fields, e.g. "id", "name" and "email", only requests those.
+func (c *Client) GetPerson(personID int, fields ...string) (*Person, error) {
+	if personID < 1 {
+		return nil, errors.New("Person ID must be positive")
+	}
+
+	path := fmt.Sprintf("/persons/%d", personID)
+	if len(fields) > 0 {
+		path += "?" + url.Values{"fields": {strings.Join(fields, ",")}}.Encode()
+	}
+
+	var p *Person
+	if _, err := c.getEntity(path, &p); err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	return p, nil
+}
+
+// GetPersons returns the Persons with the given IDs keyed by ID, fetching a
+// few at a time. IDs that aren't found are left out of the map.
+func (c *Client) GetPersons(ids []int) (map[int]*Person, error) {
+	for _, id := range ids {
+		if id < 1 {
+			return nil, errors.New("Person ID must be positive")
+		}
+	}
+
+	persons := map[int]*Person{}
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		seen     = map[int]bool{}
+		slots    = make(chan struct{}, maxConcurrentRequests)
+	)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		// Taking a slot before starting the goroutine keeps large batches
+		// from piling up goroutines waiting for one
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			p, err := c.GetPerson(id)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				persons[id] = p
+			case errors.Is(err, ErrNotFound):
+			case firstErr == nil:
+				firstErr = err
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return persons, nil
+}
+
+// DeletePerson deletes an existing Person
+func (c *Client) DeletePerson(personID int) error {
+	if personID < 1 {
+		return errors.New("Person ID must be positive")
+	}
+
+	return c.delete(fmt.Sprintf("/persons/%d", personID), nil)
+}
+
+// SetPersonActive activates or deactivates an existing Person. Deactivating
+// keeps the Person and its history, unlike deleting it.
+func (c *Client) SetPersonActive(personID int, active bool) error {
+	if personID < 1 {
+		return errors.New("Person ID must be positive")
+	}
+
+	return c.put(fmt.Sprintf("/persons/%d", personID), map[string]interface{}{
+		"active_flag": active,
+	}, nil)
+}
@@ -0,0 +1,141 @@
+package pipedrive
+
+import (
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_GetPersons(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/1?api_token=abc123": `{"success": true, "data": {"id": 1, "name": "Tester McTest"}}`,
+				"http://base/v1/persons/2?api_token=abc123": `{"success": true, "data": {"id": 2, "name": "Other Person"}}`,
+				"http://base/v1/persons/3?api_token=abc123": `{"success": false, "error": "Person not found"}`,
+				"http://base/v1/persons/4?api_token=abc123": `{"success": true, "data": null}`,
+				"http://base/v1/persons/5?api_token=abc123": `{"success": false, "error": "Server error"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/persons/3?api_token=abc123": http.StatusNotFound,
+				"http://base/v1/persons/5?api_token=abc123": http.StatusInternalServerError,
+			},
+		},
+	})
+
+	persons, err := client.GetPersons([]int{1, 2, 3, 4, 2})
+	if err != nil {
+		t.Errorf("Unexpected error fetching persons: %+v", err)
+		return
+	}
+	if len(persons) != 2 || persons[1].Name != "Tester McTest" || persons[2].Name != "Other Person" {
+		t.Errorf("Expected persons 1 and 2; got %+v", persons)
+	}
+
+	if _, err = client.GetPersons([]int{1, 5}); err == nil {
+		t.Error("Expected error fetching persons when a request fails")
+	}
+	if _, err = client.GetPersons([]int{1, 0}); err == nil {
+		t.Error("Expected error fetching person with no ID")
+	}
+	if _, err = client.GetPerson(4); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound; got %+v", err)
+	}
+}
+
+func Test_GetPersons_Bounded(t *testing.T) {
+	ids := make([]int, 1000)
+	blocked := &blockingClient{started: make(chan struct{}, len(ids)), release: make(chan struct{})}
+	client := NewClient("http://base", "abc123", ClientOptions{HTTPClient: blocked})
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	before := runtime.NumGoroutine()
+	done := make(chan error)
+	go func() {
+		_, err := client.GetPersons(ids)
+		done <- err
+	}()
+
+	for i := 0; i < maxConcurrentRequests; i++ {
+		<-blocked.started
+	}
+	if running := runtime.NumGoroutine() - before; running > maxConcurrentRequests+2 {
+		t.Errorf("Expected at most %d goroutines for the batch; got %d", maxConcurrentRequests+2, running)
+	}
+	close(blocked.release)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error fetching persons: %+v", err)
+	}
+}
+
+// blockingClient holds every request until release is closed, signaling
+// started as each one arrives
+type blockingClient struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingClient) Do(req *http.Request) (*http.Response, error) {
+	c.started <- struct{}{}
+	<-c.release
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"success": true, "data": {"id": 1}}`)),
+	}, nil
+}
+
+func Test_GetPerson_Fields(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/1?api_token=abc123&fields=id%2Cname%2Cemail": `{"success": true, "data": {"id": 1, "name": "Tester McTest", "email": [{"value": "test@videofruit.com", "primary": true}]}}`,
+			},
+		},
+	})
+
+	p, err := client.GetPerson(1, "id", "name", "email")
+	if err != nil {
+		t.Errorf("Unexpected error fetching person: %+v", err)
+		return
+	}
+	if p.ID != 1 || p.Name != "Tester McTest" || len(p.Email) != 1 || p.Email[0] != "test@videofruit.com" {
+		t.Errorf("Unexpected person: %+v", p)
+	}
+}
+
+func Test_SetPersonActive(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/2?api_token=abc123": `{"success": true, "data": {"id": 2, "name": "Jane Doe", "active_flag": false}}`,
+				"http://base/v1/persons/3?api_token=abc123": `{"success": false, "error": "Person not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/persons/3?api_token=abc123": http.StatusNotFound,
+			},
+			sent: sent,
+		},
+	})
+
+	if err := client.SetPersonActive(2, false); err != nil {
+		t.Errorf("Unexpected error deactivating person: %+v", err)
+		return
+	}
+
+	expected := `{"active_flag":false}`
+	if actual := sent["http://base/v1/persons/2?api_token=abc123"]; actual != expected {
+		t.Errorf("Deactivate body want %s; got %s", expected, actual)
+	}
+
+	if err := client.SetPersonActive(0, false); err == nil {
+		t.Error("Expected error deactivating person with no ID")
+	}
+	err := client.SetPersonActive(3, true)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected not found API error; got %+v", err)
+	}
+}
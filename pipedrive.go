@@ -2,44 +2,302 @@ package pipedrive
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 )
 
-// Requestor in an interface matching http.Client
+// Requestor is an interface matching http.Client, which the client sends every
+// request through
 type Requestor interface {
-	Get(string) (*http.Response, error)
-	Post(string, string, io.Reader) (*http.Response, error)
+	Do(*http.Request) (*http.Response, error)
 }
 
 // ClientOptions specifies options when creating a new Client
 type ClientOptions struct {
-	HTTPClient    Requestor
+	HTTPClient Requestor
+	// Transport replaces the default transport, e.g. to use a proxy or custom
+	// TLS config. Ignored when HTTPClient is set.
+	Transport http.RoundTripper
+	// DefaultUserID owns created entities that don't name an owner: it is
+	// sent as owner_id for Persons, Organizations and Leads (OwnerID), and as
+	// user_id for Deals and Activities (UserID). The owner is left to
+	// PipeDrive when neither is set.
 	DefaultUserID int
+	// DefaultVisibleTo is the visible_to setting of created deals, persons and
+	// organizations. Left to PipeDrive when 0.
+	DefaultVisibleTo int
+	// ExactOrganizationMatch makes organization lookups only accept
+	// organizations named exactly as requested instead of PipeDrive's fuzzy
+	// matches
+	ExactOrganizationMatch bool
+	// UserAgent is sent with every request. Defaults to DefaultUserAgent.
+	UserAgent string
+	// APIVersion is the path prefix added to the BaseURL, e.g. "v1" or
+	// "api/v2". Defaults to "v1".
+	APIVersion string
+	// IdempotencyTTL is how long keys passed to the *Idempotent methods are
+	// remembered. Defaults to DefaultIdempotencyTTL.
+	IdempotencyTTL time.Duration
+	// DryRun makes POST, PUT and DELETE requests log what they would send and
+	// succeed without reaching PipeDrive. GET requests are sent as usual.
+	DryRun bool
+	// Logger receives debugging messages, such as the requests skipped by
+	// DryRun. Nothing is logged when nil.
+	Logger Logger
+	// OnRequest is called with the stats of every request sent to PipeDrive,
+	// e.g. to record metrics. It is called once per request, after any
+	// retries.
+	OnRequest func(RequestStats)
+	// MaxRetries is how many times a failed request is sent again. Requests
+	// aren't retried when 0.
+	MaxRetries int
+	// IsRetryable decides which failed requests are retried. Defaults to
+	// DefaultIsRetryable.
+	IsRetryable func(resp *http.Response, err error) bool
+	// ResolveFieldNames lets Deal.Fields be keyed by field names, such as
+	// "Contract Length", which CreateDeal resolves to the field keys
+	ResolveFieldNames bool
+	// ExternalIDField is the key, or name with ResolveFieldNames, of the
+	// custom Deal field holding Deal.ExternalID
+	ExternalIDField string
+	// RequestIDHeader is the header carrying the ID set with WithRequestID,
+	// e.g. to correlate requests in tracing. Defaults to
+	// DefaultRequestIDHeader.
+	RequestIDHeader string
+	// MaxResponseBytes caps the size of a response body, guarding against
+	// runaway responses. 0 means DefaultMaxResponseBytes; negative means
+	// unlimited.
+	MaxResponseBytes int64
+	// Clock is used to wait between retries and polls. Defaults to the system
+	// clock; tests can fast-forward it instead of waiting.
+	Clock Clock
+	// LeadConversionTimeout is how long ConvertLead waits for PipeDrive to
+	// convert a Lead. Defaults to DefaultLeadConversionTimeout.
+	LeadConversionTimeout time.Duration
 }
 
-// Client represents a PipeDrive API client wrapper
+// Clock tells the time and waits
+type Clock interface {
+	Now() time.Time
+	Sleep(time.Duration)
+}
+
+// systemClock is the Clock used unless ClientOptions.Clock is set
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// Logger is implemented by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// RequestStats describes a request sent to PipeDrive. StatusCode is 0 when no
+// response was received.
+type RequestStats struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	RetryCount int
+}
+
+// Client represents a PipeDrive API client wrapper. A Client is safe for
+// concurrent use by multiple goroutines as long as its exported fields aren't
+// modified; any state it keeps internally must be guarded accordingly. Use
+// SetAPIToken to change the token of a Client in use.
 type Client struct {
-	APIToken      string
-	BaseURL       string
-	DefaultUserID int
-	httpClient    Requestor
+	APIToken               string
+	BaseURL                string
+	APIVersion             string
+	UserAgent              string
+	DefaultUserID          int
+	DefaultVisibleTo       int
+	ExactOrganizationMatch bool
+	DryRun                 bool
+	Logger                 Logger
+	OnRequest              func(RequestStats)
+	MaxRetries             int
+	IsRetryable            func(resp *http.Response, err error) bool
+	ResolveFieldNames      bool
+	ExternalIDField        string
+	RequestIDHeader        string
+	// MaxResponseBytes caps the size of a response body. 0 means
+	// DefaultMaxResponseBytes; negative means unlimited.
+	MaxResponseBytes int64
+	// LeadConversionTimeout is how long ConvertLead waits. 0 means
+	// DefaultLeadConversionTimeout.
+	LeadConversionTimeout time.Duration
+	httpClient            Requestor
+	idempotency           *idempotencyCache
+	fieldCache            *fieldCache
+	clock                 Clock
+	// requestID is sent in the RequestIDHeader when set with WithRequestID
+	requestID string
+	// mu guards APIToken for SetAPIToken and lastHeader
+	mu         *sync.RWMutex
+	lastHeader http.Header
+}
+
+// DefaultMaxResponseBytes is the response size limit used when
+// MaxResponseBytes is 0
+const DefaultMaxResponseBytes = 10 << 20
+
+// DefaultRequestIDHeader is the header carrying request IDs when
+// ClientOptions.RequestIDHeader isn't set
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// DefaultUserAgent is the User-Agent sent when UserAgent isn't set
+const DefaultUserAgent = "carolineleeck-pipedrive-go"
+
+// versionedBaseURL matches a BaseURL that already ends in an API version
+var versionedBaseURL = regexp.MustCompile(`/(api/)?v\d+$`)
+
+// ErrNotFound is returned by find and get methods when nothing matches
+var ErrNotFound = errors.New("Pipedrive entity not found")
+
+// APIError is returned when PipeDrive responds with a non-2xx status code or
+// with something other than JSON
+type APIError struct {
+	StatusCode int
+	Body       string
+	// Message explains the error when the Body doesn't, e.g. for HTML pages
+	Message string
+	// Header holds the response headers, e.g. PipeDrive's request ID
+	Header http.Header
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("Pipedrive API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("Pipedrive API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// Is makes a 404 APIError match ErrNotFound
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
 }
 
 // Person is a PipeDrive Person representation
 type Person struct {
-	ID             int      `json:"id"`
-	OwnerID        int      `json:"owner_id"`
-	OrganizationID int      `json:"org_id"`
-	Name           string   `json:"name"`
-	Email          []string `json:"email"`
-	Phone          []string `json:"phone"`
+	ID             int `json:"id"`
+	OwnerID        int `json:"owner_id"`
+	OrganizationID int `json:"org_id"`
+	// OrganizationName finds or creates the organization of a new Person
+	// when OrganizationID isn't set
+	OrganizationName string         `json:"org_name"`
+	Name             string         `json:"name"`
+	Email            []string       `json:"email"`
+	Phone            []ContactField `json:"phone"`
+	// CreatedAt is sent when creating a Person if set, e.g. to keep the
+	// original date of migrated records
+	CreatedAt PipedriveTime `json:"add_time"`
+	// Owner is the owning User when PipeDrive sends it along with OwnerID
+	Owner *User `json:"-"`
+}
+
+// ContactField is a labelled phone number of a Person, e.g. labelled "work"
+// or "mobile"
+type ContactField struct {
+	Value   string `json:"value"`
+	Label   string `json:"label,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// UnmarshalJSON decodes a Person, accepting the owner and organization either
+// as plain IDs or as nested objects, and emails and phones either as strings
+// or as the labelled objects PipeDrive returns on reads
+func (p *Person) UnmarshalJSON(b []byte) error {
+	type person Person
+	aux := struct {
+		*person
+		OwnerID        relatedUser   `json:"owner_id"`
+		OrganizationID relatedID     `json:"org_id"`
+		Email          contactValues `json:"email"`
+		Phone          contactFields `json:"phone"`
+	}{
+		person:         (*person)(p),
+		OwnerID:        relatedUser{ID: relatedID(p.OwnerID), User: p.Owner},
+		OrganizationID: relatedID(p.OrganizationID),
+		Email:          contactValues(p.Email),
+		Phone:          contactFields(p.Phone),
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	p.OwnerID = int(aux.OwnerID.ID)
+	p.Owner = aux.OwnerID.User
+	p.OrganizationID = int(aux.OrganizationID)
+	p.Email = []string(aux.Email)
+	p.Phone = []ContactField(aux.Phone)
+	return nil
+}
+
+// contactFields are the emails or phones of a Person, which PipeDrive sends
+// as a string, a list of strings or a list of labelled objects. Empty values
+// are dropped.
+type contactFields []ContactField
+
+func (cf *contactFields) UnmarshalJSON(b []byte) error {
+	*cf = nil
+	if string(b) == "null" {
+		return nil
+	}
+
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		if single != "" {
+			*cf = contactFields{{Value: single}}
+		}
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		var field ContactField
+		if err := json.Unmarshal(item, &field.Value); err != nil {
+			if err := json.Unmarshal(item, &field); err != nil {
+				return err
+			}
+		}
+		if field.Value != "" {
+			*cf = append(*cf, field)
+		}
+	}
+	return nil
+}
+
+// contactValues are the values of contactFields, dropping their labels
+type contactValues []string
+
+func (cv *contactValues) UnmarshalJSON(b []byte) error {
+	var fields contactFields
+	if err := fields.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	*cv = nil
+	for _, field := range fields {
+		*cv = append(*cv, field.Value)
+	}
+	return nil
 }
 
 // Organization is a PipeDrive Organization representation
@@ -48,216 +306,1051 @@ type Organization struct {
 	Name    string                 `json:"name"`
 	OwnerID int                    `json:"owner_id"`
 	Fields  map[string]interface{} `json:"fields"`
+	// The address parts are sent when creating an Organization if set
+	AddressStreet     string `json:"address_route"`
+	AddressLocality   string `json:"address_locality"`
+	AddressPostalCode string `json:"address_postal_code"`
+	AddressCountry    string `json:"address_country"`
+	// CreatedAt is sent when creating an Organization if set, e.g. to keep
+	// the original date of migrated records
+	CreatedAt PipedriveTime `json:"add_time"`
+	// Owner is the owning User when PipeDrive sends it along with OwnerID
+	Owner *User `json:"-"`
+}
+
+// UnmarshalJSON decodes an Organization, accepting the owner either as a plain
+// ID or as the nested object PipeDrive returns on reads
+func (o *Organization) UnmarshalJSON(b []byte) error {
+	type organization Organization
+	aux := struct {
+		*organization
+		OwnerID relatedUser `json:"owner_id"`
+	}{organization: (*organization)(o), OwnerID: relatedUser{ID: relatedID(o.OwnerID), User: o.Owner}}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	o.OwnerID = int(aux.OwnerID.ID)
+	o.Owner = aux.OwnerID.User
+	return nil
+}
+
+// DealStatus is the status of a Deal
+type DealStatus string
+
+// The statuses a Deal can have
+const (
+	DealStatusOpen    DealStatus = "open"
+	DealStatusWon     DealStatus = "won"
+	DealStatusLost    DealStatus = "lost"
+	DealStatusDeleted DealStatus = "deleted"
+)
+
+// Validate returns an error unless s is one of the DealStatus constants
+func (s DealStatus) Validate() error {
+	switch s {
+	case DealStatusOpen, DealStatusWon, DealStatusLost, DealStatusDeleted:
+		return nil
+	}
+	return fmt.Errorf("Invalid deal status %q", string(s))
 }
 
 // Deal is a PipeDrive Deal representation
 type Deal struct {
-	ID             int                    `json:"id"`
-	Title          string                 `json:"title"`
-	Value          int                    `json:"value"`
-	UserID         int                    `json:"user_id"`
-	PersonID       int                    `json:"person_id"`
-	OrganizationID int                    `json:"org_id"`
-	StageID        int                    `json:"stage_id"`
-	Fields         map[string]interface{} `json:"fields"`
+	ID             int        `json:"id"`
+	Title          string     `json:"title"`
+	Value          float64    `json:"value"`
+	Currency       string     `json:"currency"`
+	UserID         int        `json:"user_id"`
+	PersonID       int        `json:"person_id"`
+	OrganizationID int        `json:"org_id"`
+	StageID        int        `json:"stage_id"`
+	PipelineID     int        `json:"pipeline_id"`
+	Status         DealStatus `json:"status"`
+	// CreatedAt is sent when creating a Deal if set, e.g. to keep the
+	// original date of migrated records
+	CreatedAt PipedriveTime          `json:"add_time"`
+	UpdatedAt PipedriveTime          `json:"update_time"`
+	Fields    map[string]interface{} `json:"fields"`
+	// Owner is the owning User when PipeDrive sends it along with UserID
+	Owner *User `json:"-"`
+	// ExternalID identifies the Deal in another system. It is stored in the
+	// ClientOptions.ExternalIDField custom field when creating the Deal.
+	ExternalID string `json:"-"`
+}
+
+// UnmarshalJSON decodes a Deal, accepting the related user, person and
+// organization either as plain IDs or as the nested objects PipeDrive returns
+// on reads
+func (d *Deal) UnmarshalJSON(b []byte) error {
+	type deal Deal
+	aux := struct {
+		*deal
+		UserID         relatedUser `json:"user_id"`
+		PersonID       relatedID   `json:"person_id"`
+		OrganizationID relatedID   `json:"org_id"`
+	}{
+		deal:           (*deal)(d),
+		UserID:         relatedUser{ID: relatedID(d.UserID), User: d.Owner},
+		PersonID:       relatedID(d.PersonID),
+		OrganizationID: relatedID(d.OrganizationID),
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	d.UserID = int(aux.UserID.ID)
+	d.Owner = aux.UserID.User
+	d.PersonID = int(aux.PersonID)
+	d.OrganizationID = int(aux.OrganizationID)
+	return nil
+}
+
+// relatedID is the ID of a related entity, which PipeDrive sends either as a
+// plain number or as an object carrying the ID in "value" or "id"
+type relatedID int
+
+func (r *relatedID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*r = 0
+		return nil
+	}
+
+	var id int
+	if err := json.Unmarshal(b, &id); err == nil {
+		*r = relatedID(id)
+		return nil
+	}
+
+	var obj struct {
+		Value int `json:"value"`
+		ID    int `json:"id"`
+	}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+	if obj.Value != 0 {
+		*r = relatedID(obj.Value)
+	} else {
+		*r = relatedID(obj.ID)
+	}
+	return nil
+}
+
+// relatedUser is a related User, such as an owner, which PipeDrive sends
+// either as a plain ID or as an object describing the User
+type relatedUser struct {
+	ID   relatedID
+	User *User
+}
+
+func (r *relatedUser) UnmarshalJSON(b []byte) error {
+	if err := r.ID.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	r.User = nil
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		return nil
+	}
+	var user User
+	if err := json.Unmarshal(b, &user); err != nil {
+		return err
+	}
+	user.ID = int(r.ID)
+	r.User = &user
+	return nil
 }
 
-// NewClient returns a properly initialzed API client
+// apiResponse is the envelope PipeDrive wraps every response in
+type apiResponse struct {
+	Success        bool            `json:"success"`
+	Error          string          `json:"error"`
+	Data           json.RawMessage `json:"data"`
+	AdditionalData struct {
+		Pagination Pagination `json:"pagination"`
+	} `json:"additional_data"`
+}
+
+// Pagination describes a page of a list. When MoreItems is set, the next page
+// begins at NextStart.
+type Pagination struct {
+	Start     int  `json:"start"`
+	Limit     int  `json:"limit"`
+	MoreItems bool `json:"more_items_in_collection"`
+	NextStart int  `json:"next_start"`
+}
+
+// NewClient returns a properly initialzed API client. Trailing slashes are
+// stripped from baseURL; a baseURL that isn't an absolute URL causes every
+// request to fail with an error.
 func NewClient(baseURL, apiToken string, opts ClientOptions) *Client {
 	client := &Client{
-		APIToken:      apiToken,
-		BaseURL:       baseURL,
-		DefaultUserID: opts.DefaultUserID,
+		APIToken:               apiToken,
+		BaseURL:                strings.TrimRight(baseURL, "/"),
+		APIVersion:             opts.APIVersion,
+		UserAgent:              opts.UserAgent,
+		DefaultUserID:          opts.DefaultUserID,
+		DefaultVisibleTo:       opts.DefaultVisibleTo,
+		ExactOrganizationMatch: opts.ExactOrganizationMatch,
+		DryRun:                 opts.DryRun,
+		Logger:                 opts.Logger,
+		OnRequest:              opts.OnRequest,
+		MaxRetries:             opts.MaxRetries,
+		IsRetryable:            opts.IsRetryable,
+		ResolveFieldNames:      opts.ResolveFieldNames,
+		ExternalIDField:        opts.ExternalIDField,
+		MaxResponseBytes:       opts.MaxResponseBytes,
+		LeadConversionTimeout:  opts.LeadConversionTimeout,
+		RequestIDHeader:        opts.RequestIDHeader,
+		fieldCache:             newFieldCache(),
+		clock:                  opts.Clock,
+		mu:                     &sync.RWMutex{},
+	}
+	if client.APIVersion == "" {
+		client.APIVersion = "v1"
+	}
+	if client.UserAgent == "" {
+		client.UserAgent = DefaultUserAgent
+	}
+	if client.clock == nil {
+		client.clock = systemClock{}
+	}
+	client.idempotency = newIdempotencyCache(opts.IdempotencyTTL, client.clock.Now)
+	if client.RequestIDHeader == "" {
+		client.RequestIDHeader = DefaultRequestIDHeader
 	}
 
 	if opts.HTTPClient != nil {
 		client.httpClient = opts.HTTPClient
 	} else {
-		client.httpClient = &http.Client{
-			Timeout: time.Second * 10,
-			Transport: &http.Transport{
-				Dial: (&net.Dialer{
-					Timeout: time.Second * 5,
-				}).Dial,
-				TLSHandshakeTimeout: time.Second * 5,
-			},
-		}
+		client.httpClient = newHTTPClient(opts.Transport)
 	}
 
 	return client
 }
 
-// FindOrCreateOrganization searches for an Organization by name and creates a
-// new one if it doesn't exist
-func (c *Client) FindOrCreateOrganization(org *Organization) error {
-	authedURL, err := c.authenticatedURL("/organizations/find?term=" + org.Name)
-	if err != nil {
-		return err
+// newHTTPClient returns the HTTP client used when ClientOptions.HTTPClient
+// isn't set, with the default transport unless one is given
+func newHTTPClient(transport http.RoundTripper) *http.Client {
+	if transport == nil {
+		transport = &http.Transport{
+			Dial: (&net.Dialer{
+				Timeout: time.Second * 5,
+			}).Dial,
+			TLSHandshakeTimeout: time.Second * 5,
+		}
+	}
+	return &http.Client{
+		Timeout:   time.Second * 10,
+		Transport: transport,
+	}
+}
+
+// defaultHTTPClient sends the requests of Clients not created with NewClient
+var defaultHTTPClient = newHTTPClient(nil)
+
+// requestor returns the HTTP client, falling back to defaultHTTPClient for a
+// Client built as a struct literal
+func (c *Client) requestor() Requestor {
+	if c.httpClient == nil {
+		return defaultHTTPClient
+	}
+	return c.httpClient
+}
+
+// pause waits d between retries and polls on the client's Clock, falling back
+// to time.Sleep for a Client built as a struct literal
+func (c *Client) pause(d time.Duration) {
+	if c.clock == nil {
+		time.Sleep(d)
+		return
+	}
+	c.clock.Sleep(d)
+}
+
+// now returns the time on the client's Clock, falling back to time.Now for a
+// Client built as a struct literal
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// WithBaseURL returns a copy of the client sending requests to baseURL, e.g.
+// to target a sandbox account with the same configuration. The copy shares the
+// HTTP client but not the idempotency keys or field definitions, as those
+// differ between accounts.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	clone := c.clone()
+	clone.BaseURL = strings.TrimRight(baseURL, "/")
+	if c.idempotency != nil {
+		clone.idempotency = newIdempotencyCache(c.idempotency.ttl, c.idempotency.now)
+	}
+	if c.fieldCache != nil {
+		clone.fieldCache = newFieldCache()
+	}
+	return clone
+}
+
+// WithTimeout returns a copy of the client whose requests time out after d,
+// e.g. to give slow list endpoints longer than point reads. The copy shares
+// the client's caches. A custom ClientOptions.HTTPClient other than an
+// *http.Client can't be tuned and is used as is.
+func (c *Client) WithTimeout(d time.Duration) *Client {
+	clone := c.clone()
+	if httpClient, ok := c.requestor().(*http.Client); ok {
+		tuned := *httpClient
+		tuned.Timeout = d
+		clone.httpClient = &tuned
+	}
+	return clone
+}
+
+// WithRequestID returns a copy of the client sending id in the
+// RequestIDHeader of every request, e.g. the ID of the incoming request being
+// handled so PipeDrive calls can be traced back to it. The copy shares the
+// client's caches.
+func (c *Client) WithRequestID(id string) *Client {
+	clone := c.clone()
+	clone.requestID = id
+	return clone
+}
+
+// withAPIVersion returns a copy of the client sending requests to the given
+// API version, e.g. for endpoints only available in a newer version
+func (c *Client) withAPIVersion(version string) *Client {
+	clone := c.clone()
+	clone.BaseURL = versionedBaseURL.ReplaceAllString(c.BaseURL, "")
+	clone.APIVersion = version
+	return clone
+}
+
+// clone copies the client. The copy gets its own token lock, so its token
+// can be changed independently.
+func (c *Client) clone() *Client {
+	if c.mu != nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+
+	clone := *c
+	clone.mu = &sync.RWMutex{}
+	clone.lastHeader = nil
+	return &clone
+}
+
+// SetAPIToken replaces the API token used by subsequent requests, e.g. when
+// rotating tokens. It is safe to call while the client is in use.
+func (c *Client) SetAPIToken(token string) {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.APIToken = token
+}
+
+// LastResponseHeaders returns the headers of the last response the client
+// received, e.g. to find PipeDrive's request ID or rate limit headers. With
+// concurrent requests, the last response may belong to any of them.
+func (c *Client) LastResponseHeaders() http.Header {
+	if c.mu != nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
 	}
+	return c.lastHeader.Clone()
+}
+
+func (c *Client) setLastHeader(header http.Header) {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.lastHeader = header
+}
+
+// ownerID returns the owner to send for a created entity: its own owner when
+// set, DefaultUserID otherwise, or 0 to leave it out
+func (c *Client) ownerID(explicit int) int {
+	if explicit != 0 {
+		return explicit
+	}
+	return c.DefaultUserID
+}
+
+// apiToken returns the API token, guarded against concurrent SetAPIToken calls
+func (c *Client) apiToken() string {
+	if c.mu != nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return c.APIToken
+}
 
-	resp, err := c.httpClient.Get(authedURL.String())
+// FindOrganization searches for an Organization by name, returning
+// ErrNotFound when there is no match. An Organization named exactly as
+// requested is preferred over PipeDrive's first fuzzy match.
+func (c *Client) FindOrganization(name string) (*Organization, error) {
+	found, err := c.FindOrganizations(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var data map[string]interface{}
-	buf := new(bytes.Buffer)
-	_, err = buf.ReadFrom(resp.Body)
+	for i, candidate := range found {
+		if candidate.Name == name {
+			return &found[i], nil
+		}
+	}
+	if c.ExactOrganizationMatch {
+		return nil, ErrNotFound
+	}
+	return &found[0], nil
+}
+
+// FindOrganizations searches for Organizations by name, returning every
+// candidate or ErrNotFound when there is no match
+func (c *Client) FindOrganizations(term string) ([]Organization, error) {
+	if term == "" {
+		return nil, errors.New("Must have a name")
+	}
+
+	var found []Organization
+	_, err := c.getEntity("/organizations/find?term="+url.QueryEscape(term), &found)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = json.Unmarshal(buf.Bytes(), &data); err != nil {
-		return err
+	if len(found) == 0 {
+		return nil, ErrNotFound
 	}
+	return found, nil
+}
 
-	if data["data"] != nil {
-		// This will likely crash us...
-		org.ID = int(data["data"].([]interface{})[0].(map[string]interface{})["id"].(float64))
-	} else {
-		postStruct := map[string]interface{}{
-			"name": org.Name,
-		}
-		for name, value := range org.Fields {
-			postStruct[name] = value
-		}
+// FindOrCreateOrganization searches for an Organization by name and creates a
+// new one if it doesn't exist
+func (c *Client) FindOrCreateOrganization(org *Organization) error {
+	_, err := c.FindOrCreateOrganizationEx(org)
+	return err
+}
 
-		if c.DefaultUserID != 0 {
-			postStruct["owner_id"] = c.DefaultUserID
-		}
-		data, err := c.createEntity("/organizations", postStruct)
-		if err != nil {
-			return err
-		}
+// FindOrCreateOrganizationEx is FindOrCreateOrganization, also reporting
+// whether the Organization was created rather than found
+func (c *Client) FindOrCreateOrganizationEx(org *Organization) (bool, error) {
+	found, err := c.FindOrganization(org.Name)
+	if err == nil {
+		org.ID = found.ID
+		return false, nil
+	}
+	if err != ErrNotFound {
+		return false, err
+	}
 
-		if data["data"] != nil {
-			org.ID = int(data["data"].(map[string]interface{})["id"].(float64))
-		} else {
-			return fmt.Errorf("Error creating Pipedrive org: %s", buf.String())
+	if err = c.CreateOrganization(org); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateOrganization creates a new Organization from the initialized
+// Organization without checking whether it already exists
+func (c *Client) CreateOrganization(org *Organization) error {
+	if org.Name == "" {
+		return errors.New("Must have a name")
+	}
+
+	postStruct := map[string]interface{}{
+		"name": org.Name,
+	}
+	for name, value := range org.Fields {
+		postStruct[name] = value
+	}
+	address := map[string]string{
+		"address_route":       org.AddressStreet,
+		"address_locality":    org.AddressLocality,
+		"address_postal_code": org.AddressPostalCode,
+		"address_country":     org.AddressCountry,
+	}
+	for name, value := range address {
+		if value != "" {
+			postStruct[name] = value
 		}
 	}
 
+	if ownerID := c.ownerID(org.OwnerID); ownerID != 0 {
+		postStruct["owner_id"] = ownerID
+	}
+	if c.DefaultVisibleTo != 0 {
+		postStruct["visible_to"] = c.DefaultVisibleTo
+	}
+	if !org.CreatedAt.IsZero() {
+		postStruct["add_time"] = org.CreatedAt
+	}
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := c.post("/organizations", postStruct, &created); err != nil {
+		return err
+	}
+
+	org.ID = created.ID
 	return nil
 }
 
-// FindOrCreatePerson creates a new Person from the initialized Person
-func (c *Client) FindOrCreatePerson(newPerson *Person) error {
-	if len(newPerson.Email) < 1 {
-		return errors.New("Must have at least one email")
+// FindPerson searches for a Person by email, returning ErrNotFound when there
+// is no match
+func (c *Client) FindPerson(email string) (*Person, error) {
+	if email == "" {
+		return nil, errors.New("Must have an email")
 	}
-	authedURL, err := c.authenticatedURL("/persons/find?search_by_email=1&term=" + newPerson.Email[0])
+
+	var found []Person
+	_, err := c.getEntity("/persons/find?search_by_email=1&term="+url.QueryEscape(email), &found)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	resp, err := c.httpClient.Get(authedURL.String())
-	if err != nil {
-		return err
+	if len(found) == 0 {
+		return nil, ErrNotFound
 	}
+	return &found[0], nil
+}
 
-	var data map[string]interface{}
-	buf := new(bytes.Buffer)
-	_, err = buf.ReadFrom(resp.Body)
+// FindPersonByName searches for Persons by name, returning every candidate or
+// ErrNotFound when there is no match
+func (c *Client) FindPersonByName(name string) ([]Person, error) {
+	if name == "" {
+		return nil, errors.New("Must have a name")
+	}
+
+	var found []Person
+	_, err := c.getEntity("/persons/find?term="+url.QueryEscape(name), &found)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = json.Unmarshal(buf.Bytes(), &data); err != nil {
-		return err
+	if len(found) == 0 {
+		return nil, ErrNotFound
 	}
+	return found, nil
+}
 
-	if data["data"] != nil {
-		// This will likely crash us...
-		newPerson.ID = int(data["data"].([]interface{})[0].(map[string]interface{})["id"].(float64))
-	} else {
-		postStruct := map[string]interface{}{
-			"name":   newPerson.Name,
-			"email":  newPerson.Email,
-			"org_id": newPerson.OrganizationID,
-		}
-		if c.DefaultUserID != 0 {
-			postStruct["owner_id"] = c.DefaultUserID
-		}
-		data, err := c.createEntity("/persons", postStruct)
-		if err != nil {
-			return err
-		}
+// FindOrCreatePerson creates a new Person from the initialized Person
+func (c *Client) FindOrCreatePerson(newPerson *Person) error {
+	_, err := c.FindOrCreatePersonEx(newPerson)
+	return err
+}
+
+// FindOrCreatePersonEx is FindOrCreatePerson, also reporting whether the
+// Person was created rather than found
+func (c *Client) FindOrCreatePersonEx(newPerson *Person) (bool, error) {
+	if len(newPerson.Email) < 1 {
+		return false, errors.New("Must have at least one email")
+	}
+	found, err := c.FindPerson(newPerson.Email[0])
+	if err == nil {
+		newPerson.ID = found.ID
+		return false, nil
+	}
+	if err != ErrNotFound {
+		return false, err
+	}
 
-		// This will likely crash us similarly...
-		if data["data"] != nil {
-			newPerson.ID = int(data["data"].(map[string]interface{})["id"].(float64))
-		} else {
-			return fmt.Errorf("Error creating Pipedrive person: %s", buf.String())
+	if err = c.CreatePerson(newPerson); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreatePerson creates a new Person from the initialized Person without
+// checking whether it already exists. The Person must have at least one email.
+func (c *Client) CreatePerson(newPerson *Person) error {
+	if len(newPerson.Email) < 1 {
+		return errors.New("Must have at least one email")
+	}
+
+	if newPerson.OrganizationID == 0 && newPerson.OrganizationName != "" {
+		org := Organization{Name: newPerson.OrganizationName}
+		if err := c.FindOrCreateOrganization(&org); err != nil {
+			return fmt.Errorf("Error finding or creating organization: %w", err)
 		}
+		newPerson.OrganizationID = org.ID
+	}
+
+	postStruct := map[string]interface{}{
+		"name":   newPerson.Name,
+		"email":  newPerson.Email,
+		"org_id": newPerson.OrganizationID,
+	}
+	if len(newPerson.Phone) > 0 {
+		postStruct["phone"] = newPerson.Phone
+	}
+	if ownerID := c.ownerID(newPerson.OwnerID); ownerID != 0 {
+		postStruct["owner_id"] = ownerID
+	}
+	if c.DefaultVisibleTo != 0 {
+		postStruct["visible_to"] = c.DefaultVisibleTo
+	}
+	if !newPerson.CreatedAt.IsZero() {
+		postStruct["add_time"] = newPerson.CreatedAt
+	}
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := c.post("/persons", postStruct, &created); err != nil {
+		return err
 	}
 
+	newPerson.ID = created.ID
 	return nil
 }
 
-// CreateDeal creates a new Deal from the initialized Deal
+// CreateDeal creates a new Deal from the initialized Deal, which must have a
+// title and a person or organization
 func (c *Client) CreateDeal(newDeal *Deal) error {
-	if c.DefaultUserID != 0 && newDeal.UserID == 0 {
-		newDeal.UserID = c.DefaultUserID
+	if newDeal.Title == "" {
+		return errors.New("Deal requires a title")
+	}
+	if newDeal.PersonID == 0 && newDeal.OrganizationID == 0 {
+		return errors.New("Deal requires a person or organization")
+	}
+
+	fields, err := c.dealCustomFields(newDeal)
+	if err != nil {
+		return err
 	}
+
 	bodyData := map[string]interface{}{
 		"title":     newDeal.Title,
 		"value":     newDeal.Value,
-		"user_id":   newDeal.UserID,
 		"person_id": newDeal.PersonID,
 		"org_id":    newDeal.OrganizationID,
-		"stage_id":  newDeal.StageID,
 	}
-	for name, value := range newDeal.Fields {
+	if userID := c.ownerID(newDeal.UserID); userID != 0 {
+		bodyData["user_id"] = userID
+	}
+	if newDeal.StageID != 0 {
+		bodyData["stage_id"] = newDeal.StageID
+	}
+	if newDeal.PipelineID != 0 {
+		bodyData["pipeline_id"] = newDeal.PipelineID
+	}
+	if newDeal.Currency != "" {
+		bodyData["currency"] = newDeal.Currency
+	}
+	if c.DefaultVisibleTo != 0 {
+		bodyData["visible_to"] = c.DefaultVisibleTo
+	}
+	if !newDeal.CreatedAt.IsZero() {
+		bodyData["add_time"] = newDeal.CreatedAt
+	}
+	for name, value := range fields {
 		bodyData[name] = value
 	}
 
-	data, err := c.createEntity("/deals", bodyData)
-	if err != nil {
+	// The created deal carries the values PipeDrive filled in or normalized,
+	// such as the stage, pipeline and timestamps
+	created := *newDeal
+	if err := c.post("/deals", bodyData, &created); err != nil {
 		return err
 	}
 
-	// Also crashing
-	if data["data"] != nil {
-		newDeal.ID = int(data["data"].(map[string]interface{})["id"].(float64))
-	} else {
-		return fmt.Errorf("Error creating Pipedrive deal: %+v", data)
+	*newDeal = created
+	return nil
+}
+
+// dealCustomFields returns the custom fields of d to send, including its
+// ExternalID, keyed by field key
+func (c *Client) dealCustomFields(d *Deal) (map[string]interface{}, error) {
+	fields := d.Fields
+	if d.ExternalID != "" {
+		if c.ExternalIDField == "" {
+			return nil, errors.New("ExternalIDField must be set to save a Deal with an external ID")
+		}
+		fields = make(map[string]interface{}, len(d.Fields)+1)
+		for name, value := range d.Fields {
+			fields[name] = value
+		}
+		fields[c.ExternalIDField] = d.ExternalID
+	}
+	if c.ResolveFieldNames && len(fields) > 0 {
+		return c.resolveFieldKeys("deal", fields)
 	}
+	return fields, nil
+}
 
-	return nil
+// UpdateDeal updates the given fields on an existing Deal
+func (c *Client) UpdateDeal(dealID int, fields map[string]interface{}) error {
+	if dealID < 1 {
+		return errors.New("Deal ID must be positive")
+	}
+
+	return c.put(fmt.Sprintf("/deals/%d", dealID), fields, nil)
+}
+
+// MoveDealToStage moves an existing Deal to the given stage
+func (c *Client) MoveDealToStage(dealID, stageID int) error {
+	if stageID < 1 {
+		return errors.New("Stage ID must be positive")
+	}
+
+	return c.UpdateDeal(dealID, map[string]interface{}{
+		"stage_id": stageID,
+	})
+}
+
+// SetDealStatus sets the status of an existing Deal, rejecting unknown
+// statuses before sending the request
+func (c *Client) SetDealStatus(dealID int, status DealStatus) error {
+	if err := status.Validate(); err != nil {
+		return err
+	}
+
+	return c.UpdateDeal(dealID, map[string]interface{}{
+		"status": status,
+	})
+}
+
+// MarkDealWon sets the status of an existing Deal to won
+func (c *Client) MarkDealWon(dealID int) error {
+	return c.SetDealStatus(dealID, DealStatusWon)
+}
+
+// MarkDealLost sets the status of an existing Deal to lost. The lost reason is
+// only sent when it isn't empty.
+func (c *Client) MarkDealLost(dealID int, reason string) error {
+	fields := map[string]interface{}{
+		"status": DealStatusLost,
+	}
+	if reason != "" {
+		fields["lost_reason"] = reason
+	}
+
+	return c.UpdateDeal(dealID, fields)
 }
 
 func (c *Client) authenticatedURL(path string) (*url.URL, error) {
-	authedURL, err := url.Parse(c.BaseURL + path)
+	base := c.BaseURL
+	// A BaseURL that already includes a version is used as is
+	if c.APIVersion != "" && !versionedBaseURL.MatchString(base) {
+		base += "/" + strings.Trim(c.APIVersion, "/")
+	}
+
+	authedURL, err := url.Parse(base + path)
 	if err != nil {
 		return authedURL, err
 	}
+	if !authedURL.IsAbs() || authedURL.Host == "" {
+		return authedURL, fmt.Errorf("Invalid Pipedrive base URL: %q", c.BaseURL)
+	}
 
 	query := authedURL.Query()
-	query.Add("api_token", c.APIToken)
+	query.Add("api_token", c.apiToken())
 	authedURL.RawQuery = query.Encode()
 	return authedURL, nil
 }
 
-func (c *Client) createEntity(path string, bodyData interface{}) (map[string]interface{}, error) {
-	var data map[string]interface{}
-	postBody, err := json.Marshal(bodyData)
+// do sends an authenticated request for path with the client's headers,
+// retrying it as configured
+func (c *Client) do(method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	reqURL, err := c.authenticatedURL(path)
 	if err != nil {
-		return data, err
+		return nil, err
 	}
-	postURL, err := c.authenticatedURL(path)
+
+	// The body is buffered so it can be sent again on retries
+	var bodyData []byte
+	if body != nil {
+		if bodyData, err = ioutil.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+
+	started := time.Now()
+	var resp *http.Response
+	retries := 0
+	for {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(bodyData)
+		}
+		req, err := http.NewRequest(method, reqURL.String(), reqBody)
+		if err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		userAgent := c.UserAgent
+		if userAgent == "" {
+			userAgent = DefaultUserAgent
+		}
+		req.Header.Set("User-Agent", userAgent)
+		if c.requestID != "" {
+			header := c.RequestIDHeader
+			if header == "" {
+				header = DefaultRequestIDHeader
+			}
+			req.Header.Set(header, c.requestID)
+		}
+		// Setting this disables the transparent decompression of
+		// http.Transport, so readResponse decompresses the body instead. This
+		// also covers custom HTTP clients.
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		resp, err = c.requestor().Do(req)
+		if resp != nil {
+			c.setLastHeader(resp.Header)
+		}
+		if !c.shouldRetry(retries, resp, err) {
+			c.reportRequest(method, path, resp, time.Since(started), retries)
+			return resp, err
+		}
+
+		discardResponse(resp)
+		c.pause(retryDelay(retries, resp))
+		retries++
+	}
+}
+
+// reportRequest hands the stats of a request to the OnRequest hook
+func (c *Client) reportRequest(method, path string, resp *http.Response, duration time.Duration, retries int) {
+	if c.OnRequest == nil {
+		return
+	}
+
+	stats := RequestStats{
+		Method:     method,
+		Path:       strings.SplitN(path, "?", 2)[0],
+		Duration:   duration,
+		RetryCount: retries,
+	}
+	if resp != nil {
+		stats.StatusCode = resp.StatusCode
+	}
+	c.OnRequest(stats)
+}
+
+// post sends bodyData to path and unmarshals the response's data into out,
+// which may be nil when the data isn't needed
+func (c *Client) post(path string, bodyData, out interface{}) error {
+	return c.send(http.MethodPost, path, bodyData, out)
+}
+
+// put sends bodyData to path and unmarshals the response's data into out,
+// which may be nil when the data isn't needed
+func (c *Client) put(path string, bodyData, out interface{}) error {
+	return c.send(http.MethodPut, path, bodyData, out)
+}
+
+// delete sends a DELETE request for path and unmarshals the response's data
+// into out, which may be nil when the data isn't needed
+func (c *Client) delete(path string, out interface{}) error {
+	return c.send(http.MethodDelete, path, nil, out)
+}
+
+// send sends bodyData as JSON, or form-encoded when it is url.Values, or as a
+// multipart form when it is a *multipartForm, or no body when it is nil, and
+// unmarshals the response's data into out. In dry run
+// mode the request is only logged and out is left untouched.
+func (c *Client) send(method, path string, bodyData, out interface{}) error {
+	data, err := c.sendNoData(method, path, bodyData)
+	if err != nil || data == nil {
+		return err
+	}
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		return fmt.Errorf("Error sending %s %s to Pipedrive: no data in response", method, path)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return decodeData(data.Data, out)
+}
+
+// sendNoData is send for endpoints that may not respond with any data. The
+// returned response is nil in dry run mode.
+func (c *Client) sendNoData(method, path string, bodyData interface{}) (*apiResponse, error) {
+	var encoded []byte
+	var reqBody io.Reader
+	contentType := ""
+	switch bodyData := bodyData.(type) {
+	case nil:
+	case url.Values:
+		encoded = []byte(bodyData.Encode())
+		reqBody = bytes.NewReader(encoded)
+		contentType = "application/x-www-form-urlencoded"
+	case *multipartForm:
+		// Files aren't worth logging in dry run mode
+		encoded = []byte(fmt.Sprintf("(%d bytes of multipart form)", len(bodyData.data)))
+		reqBody = bytes.NewReader(bodyData.data)
+		contentType = bodyData.contentType
+	default:
+		var err error
+		if encoded, err = json.Marshal(bodyData); err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(encoded)
+		contentType = "application/json"
+	}
+
+	if c.DryRun {
+		c.logf("Dry run, not sending %s %s %s", method, path, encoded)
+		return nil, nil
+	}
+
+	resp, err := c.do(method, path, reqBody, contentType)
 	if err != nil {
-		return data, err
+		return nil, err
+	}
+
+	body, err := c.readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	var data apiResponse
+	if err = json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	if !data.Success {
+		return nil, fmt.Errorf("Error sending %s %s to Pipedrive: %s", method, path, string(body))
 	}
-	postResp, err := c.httpClient.Post(postURL.String(), "application/json", bytes.NewReader(postBody))
+	return &data, nil
+}
+
+// getEntity fetches path and unmarshals the response's data into out. out is
+// left untouched when the API returns null data.
+func (c *Client) getEntity(path string, out interface{}) (apiResponse, error) {
+	var data apiResponse
+	resp, err := c.do(http.MethodGet, path, nil, "")
 	if err != nil {
 		return data, err
 	}
 
-	buf := new(bytes.Buffer)
-	_, err = buf.ReadFrom(postResp.Body)
+	body, err := c.readResponse(resp)
 	if err != nil {
 		return data, err
 	}
-	err = json.Unmarshal(buf.Bytes(), &data)
+	if err = json.Unmarshal(body, &data); err != nil {
+		return data, err
+	}
+	if !data.Success {
+		return data, fmt.Errorf("Error fetching %s from Pipedrive: %s", path, string(body))
+	}
+
+	if len(data.Data) > 0 && string(data.Data) != "null" {
+		err = decodeData(data.Data, out)
+	}
 	return data, err
 }
+
+// getAllPages fetches every page of path, handing the data of each page to
+// collect
+func (c *Client) getAllPages(path string, collect func(json.RawMessage) error) error {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	start := 0
+	for {
+		var page json.RawMessage
+		resp, err := c.getEntity(fmt.Sprintf("%s%sstart=%d", path, sep, start), &page)
+		if err != nil {
+			return err
+		}
+		if len(page) > 0 {
+			// Like decodeData, so a page's unexpected shape can't crash the
+			// caller
+			if err = safeCall(func() error { return collect(page) }); err != nil {
+				return err
+			}
+		}
+
+		pagination := resp.AdditionalData.Pagination
+		if !pagination.MoreItems || pagination.NextStart <= start {
+			return nil
+		}
+		start = pagination.NextStart
+	}
+}
+
+// logf logs a debugging message when a Logger is set
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+// decodeData unmarshals the data of a response into out. Decoding runs in
+// safeCall so an unexpected response shape can't crash the caller.
+func decodeData(data json.RawMessage, out interface{}) error {
+	return safeCall(func() error {
+		return json.Unmarshal(data, out)
+	})
+}
+
+// safeCall runs fn, converting a panic into an error. It is a last line of
+// defense for parsing code, not a substitute for handling unexpected input.
+func safeCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Recovered from panic handling Pipedrive response: %v", r)
+		}
+	}()
+
+	return fn()
+}
+
+// responseLimit returns the effective MaxResponseBytes, or a negative number
+// when responses are unlimited
+func (c *Client) responseLimit() int64 {
+	if c.MaxResponseBytes == 0 {
+		return DefaultMaxResponseBytes
+	}
+	return c.MaxResponseBytes
+}
+
+// readResponse reads and closes the body of resp, decompressing it when
+// gzipped, returning an *APIError when the status code isn't 2xx or the body
+// is an HTML page, and an error when the body exceeds MaxResponseBytes
+func (c *Client) readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	limit := c.responseLimit()
+	if limit > 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(buf.Len()) > limit {
+		return nil, fmt.Errorf("Pipedrive response exceeds %d bytes", limit)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: buf.String(), Header: resp.Header}
+	}
+	// PipeDrive may answer requests with an invalid token with its login page
+	if strings.Contains(resp.Header.Get("Content-Type"), "text/html") || bytes.HasPrefix(bytes.TrimSpace(buf.Bytes()), []byte("<")) {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       buf.String(),
+			Header:     resp.Header,
+			Message:    "Received an HTML page instead of JSON, likely because the API token or base URL is invalid",
+		}
+	}
+	return buf.Bytes(), nil
+}
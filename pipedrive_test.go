@@ -1,12 +1,18 @@
 package pipedrive
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func Test_FindOrCreatePerson_Found(t *testing.T) {
@@ -15,7 +21,7 @@ func Test_FindOrCreatePerson_Found(t *testing.T) {
 	client := NewClient("http://base", "abc123", ClientOptions{
 		HTTPClient: fakeClient{
 			reqs: map[string]string{
-				"http://base/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": fmt.Sprintf(personFindResp, expectedID, email),
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": fmt.Sprintf(personFindResp, expectedID, email),
 			},
 		},
 	})
@@ -39,8 +45,8 @@ func Test_FindOrCreatePerson_NotFound(t *testing.T) {
 	client := NewClient("http://base", "abc123", ClientOptions{
 		HTTPClient: fakeClient{
 			reqs: map[string]string{
-				"http://base/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
-				"http://base/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, expectedID, email),
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, expectedID, email),
 			},
 		},
 	})
@@ -58,53 +64,1204 @@ func Test_FindOrCreatePerson_NotFound(t *testing.T) {
 	}
 }
 
+func Test_CreatePerson(t *testing.T) {
+	email := "test@videofruit.com"
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons?api_token=abc123": fmt.Sprintf(personCreateResp, 1, email),
+			},
+		},
+	})
+
+	person := Person{Name: "Test", Email: []string{email}}
+	if err := client.CreatePerson(&person); err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+		return
+	}
+	if person.ID != 1 {
+		t.Errorf("Expected person ID 1; got %d", person.ID)
+	}
+
+	if err := client.CreatePerson(&Person{Name: "Test"}); err == nil {
+		t.Error("Expected error creating person with no email")
+	}
+}
+
+func Test_CreatePerson_Owner(t *testing.T) {
+	email := "test@videofruit.com"
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons?api_token=abc123": fmt.Sprintf(personCreateResp, 1, email),
+			},
+			sent: sent,
+		},
+		DefaultUserID: 3219426,
+	})
+
+	cases := []struct {
+		person   Person
+		expected string
+	}{
+		{Person{Name: "Test", Email: []string{email}, OwnerID: 42}, `"owner_id":42`},
+		{Person{Name: "Test", Email: []string{email}}, `"owner_id":3219426`},
+	}
+	for _, tc := range cases {
+		if err := client.CreatePerson(&tc.person); err != nil {
+			t.Errorf("Unexpected error creating person: %+v", err)
+			continue
+		}
+		if body := sent["http://base/v1/persons?api_token=abc123"]; !strings.Contains(body, tc.expected) {
+			t.Errorf("Expected %s in body; got %s", tc.expected, body)
+		}
+	}
+
+	client.DefaultUserID = 0
+	if err := client.CreatePerson(&Person{Name: "Test", Email: []string{email}}); err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+		return
+	}
+	if body := sent["http://base/v1/persons?api_token=abc123"]; strings.Contains(body, "owner_id") {
+		t.Errorf("Expected no owner in body; got %s", body)
+	}
+}
+
+func Test_FindOrCreatePerson_Phones(t *testing.T) {
+	email := "test@videofruit.com"
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, 1, email),
+			},
+			sent: sent,
+		},
+	})
+
+	person := Person{
+		Name:  "Test",
+		Email: []string{email},
+		Phone: []ContactField{
+			{Value: "555-0100", Label: "work", Primary: true},
+			{Value: "555-0199", Label: "mobile"},
+		},
+	}
+	if err := client.FindOrCreatePerson(&person); err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+		return
+	}
+
+	expected := `"phone":[{"value":"555-0100","label":"work","primary":true},{"value":"555-0199","label":"mobile"}]`
+	if actual := sent["http://base/v1/persons?api_token=abc123"]; !strings.Contains(actual, expected) {
+		t.Errorf("Expected create body to contain %s; got %s", expected, actual)
+	}
+}
+
+func Test_FindOrCreatePerson_OrganizationName(t *testing.T) {
+	email := "test@videofruit.com"
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit":                        personNoFindResp,
+				"http://base/v1/organizations?api_token=abc123":                                             fmt.Sprintf(orgCreateResp, 2, "Videofruit"),
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, 1, email),
+			},
+			sent: sent,
+		},
+	})
+
+	person := Person{Name: "Test", Email: []string{email}, OrganizationName: "Videofruit"}
+	if err := client.FindOrCreatePerson(&person); err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+		return
+	}
+
+	if person.OrganizationID != 2 {
+		t.Errorf("Expected person in organization 2; got %d", person.OrganizationID)
+	}
+	if actual := sent["http://base/v1/organizations?api_token=abc123"]; !strings.Contains(actual, `"name":"Videofruit"`) {
+		t.Errorf("Expected organization to be created; got %s", actual)
+	}
+	if actual := sent["http://base/v1/persons?api_token=abc123"]; !strings.Contains(actual, `"org_id":2`) {
+		t.Errorf("Expected person create body to link organization 2; got %s", actual)
+	}
+}
+
+func Test_FindOrCreatePerson_EmptyData(t *testing.T) {
+	email := "test@videofruit.com"
+	expectedID := 1
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": `{"success": true, "data": []}`,
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, expectedID, email),
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit":                        `{"success": true, "data": []}`,
+				"http://base/v1/organizations?api_token=abc123":                                             fmt.Sprintf(orgCreateResp, 2, "Videofruit"),
+			},
+		},
+	})
+
+	person := Person{Email: []string{email}}
+	created, err := client.FindOrCreatePersonEx(&person)
+	if err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+		return
+	}
+	if !created || person.ID != expectedID {
+		t.Errorf("Expected person %d to be created; got %d (created %v)", expectedID, person.ID, created)
+	}
+
+	org := Organization{Name: "Videofruit"}
+	created, err = client.FindOrCreateOrganizationEx(&org)
+	if err != nil {
+		t.Errorf("Unexpected error creating organization: %+v", err)
+		return
+	}
+	if !created || org.ID != 2 {
+		t.Errorf("Expected organization 2 to be created; got %d (created %v)", org.ID, created)
+	}
+}
+
+func Test_FindOrCreateEx(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=found%40videofruit.com": fmt.Sprintf(personFindResp, 1, "found@videofruit.com"),
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=new%40videofruit.com":   personNoFindResp,
+				"http://base/v1/persons?api_token=abc123":                                                    fmt.Sprintf(personCreateResp, 2, "new@videofruit.com"),
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit":                         fmt.Sprintf(orgFindResp, 3, "Videofruit"),
+				"http://base/v1/organizations/find?api_token=abc123&term=Acme":                               `{"success": true, "data": null}`,
+				"http://base/v1/organizations?api_token=abc123":                                              fmt.Sprintf(orgCreateResp, 4, "Acme"),
+			},
+		},
+	})
+
+	personCases := map[string]bool{"found@videofruit.com": false, "new@videofruit.com": true}
+	for email, expected := range personCases {
+		created, err := client.FindOrCreatePersonEx(&Person{Email: []string{email}})
+		if err != nil {
+			t.Errorf("Unexpected error finding or creating person: %+v", err)
+		} else if created != expected {
+			t.Errorf("Person %s: created want %t; got %t", email, expected, created)
+		}
+	}
+
+	orgCases := map[string]bool{"Videofruit": false, "Acme": true}
+	for name, expected := range orgCases {
+		created, err := client.FindOrCreateOrganizationEx(&Organization{Name: name})
+		if err != nil {
+			t.Errorf("Unexpected error finding or creating organization: %+v", err)
+		} else if created != expected {
+			t.Errorf("Organization %s: created want %t; got %t", name, expected, created)
+		}
+	}
+}
+
+func Test_FindPerson(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com":    fmt.Sprintf(personFindResp, 1, "test@videofruit.com"),
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=missing%40videofruit.com": personNoFindResp,
+			},
+		},
+	})
+
+	person, err := client.FindPerson("test@videofruit.com")
+	if err != nil {
+		t.Errorf("Unexpected error finding person: %+v", err)
+		return
+	}
+	if person.ID != 1 || person.Name != "Some Name" || len(person.Email) != 1 || person.Email[0] != "test@videofruit.com" {
+		t.Errorf("Unexpected person: %+v", person)
+	}
+
+	_, err = client.FindPerson("missing@videofruit.com")
+	if err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound; got %+v", err)
+	}
+}
+
+func Test_FindPersonByName(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&term=Tester+McTest": personsByNameResp,
+				"http://base/v1/persons/find?api_token=abc123&term=Nobody":        personNoFindResp,
+			},
+		},
+	})
+
+	persons, err := client.FindPersonByName("Tester McTest")
+	if err != nil {
+		t.Errorf("Unexpected error finding persons: %+v", err)
+		return
+	}
+	if len(persons) != 2 || persons[0].ID != 1 || persons[1].ID != 2 {
+		t.Errorf("Expected persons 1 and 2; got %+v", persons)
+	}
+	if persons[1].OrganizationID != 1 || len(persons[1].Email) != 0 {
+		t.Errorf("Unexpected person: %+v", persons[1])
+	}
+
+	if _, err = client.FindPersonByName("Nobody"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound; got %+v", err)
+	}
+}
+
+func Test_CreateOrganization(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations?api_token=abc123": fmt.Sprintf(orgCreateResp, 2, "Videofruit"),
+			},
+		},
+	})
+
+	org := Organization{Name: "Videofruit"}
+	if err := client.CreateOrganization(&org); err != nil {
+		t.Errorf("Unexpected error creating organization: %+v", err)
+		return
+	}
+	if org.ID != 2 {
+		t.Errorf("Expected organization ID 2; got %d", org.ID)
+	}
+
+	if err := client.CreateOrganization(&Organization{}); err == nil {
+		t.Error("Expected error creating organization with no name")
+	}
+}
+
+func Test_CreateOrganization_Address(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations?api_token=abc123": fmt.Sprintf(orgCreateResp, 2, "Videofruit"),
+			},
+			sent: sent,
+		},
+	})
+
+	org := Organization{
+		Name:              "Videofruit",
+		AddressStreet:     "Main Street",
+		AddressLocality:   "Austin",
+		AddressPostalCode: "78701",
+	}
+	if err := client.CreateOrganization(&org); err != nil {
+		t.Errorf("Unexpected error creating organization: %+v", err)
+		return
+	}
+
+	expected := `{"address_locality":"Austin","address_postal_code":"78701","address_route":"Main Street","name":"Videofruit"}`
+	if actual := sent["http://base/v1/organizations?api_token=abc123"]; actual != expected {
+		t.Errorf("Create body want %s; got %s", expected, actual)
+	}
+}
+
+func Test_FindOrganizations(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/find?api_token=abc123&term=Acme":   orgsFindResp,
+				"http://base/v1/organizations/find?api_token=abc123&term=Nobody": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	orgs, err := client.FindOrganizations("Acme")
+	if err != nil {
+		t.Errorf("Unexpected error finding organizations: %+v", err)
+		return
+	}
+	if len(orgs) != 2 || orgs[0].ID != 1 || orgs[0].Name != "Acme Corp" || orgs[1].ID != 2 || orgs[1].Name != "Acme" {
+		t.Errorf("Expected Acme Corp and Acme; got %+v", orgs)
+	}
+
+	// The exact match wins over the first fuzzy match
+	org, err := client.FindOrganization("Acme")
+	if err != nil || org.ID != 2 {
+		t.Errorf("Expected organization 2; got %+v (%+v)", org, err)
+	}
+
+	if _, err = client.FindOrganizations("Nobody"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound; got %+v", err)
+	}
+}
+
 func Test_FindOrCreateOrganization_Found(t *testing.T) {
 	name := "Videofruit"
 	expectedID := 1
 	client := NewClient("http://base", "abc123", ClientOptions{
 		HTTPClient: fakeClient{
 			reqs: map[string]string{
-				"http://base/organizations/find?api_token=abc123&term=Videofruit": fmt.Sprintf(orgFindResp, expectedID, name),
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit": fmt.Sprintf(orgFindResp, expectedID, name),
+			},
+		},
+	})
+
+	org := Organization{Name: name}
+
+	err := client.FindOrCreateOrganization(&org)
+	if err != nil {
+		t.Errorf("Unexpected error finding organization: %+v", err)
+		return
+	}
+
+	if org.ID != expectedID {
+		t.Errorf("Failed to find Organization. Expected ID to be %d; got %d", expectedID, org.ID)
+		return
+	}
+}
+func Test_FindOrCreateOrganization_NotFound(t *testing.T) {
+	expectedID := 2
+	name := "Videofruit"
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit": `{ "success": true, "data": null, "additional_data": { "pagination": { "start": 0, "limit": 100, "more_items_in_collection": false } } }`,
+				"http://base/v1/organizations?api_token=abc123":                      fmt.Sprintf(orgCreateResp, expectedID, name),
+			},
+		},
+	})
+
+	org := Organization{Name: name}
+
+	err := client.FindOrCreateOrganization(&org)
+	if err != nil {
+		t.Errorf("Unexpected error finding or creating organization: %+v", err)
+		return
+	}
+
+	if org.ID != expectedID {
+		t.Errorf("Failed to create Organization. Expected ID to be %d; got %d", expectedID, org.ID)
+		return
+	}
+}
+
+func Test_CreateDeal_Body(t *testing.T) {
+	cases := []struct {
+		deal     Deal
+		expected string
+	}{
+		{
+			Deal{Title: "Close this deal!", PersonID: 1},
+			`{"org_id":0,"person_id":1,"title":"Close this deal!","value":0}`,
+		},
+		{
+			Deal{Title: "Close this deal!", PersonID: 1, StageID: 3, PipelineID: 1},
+			`{"org_id":0,"person_id":1,"pipeline_id":1,"stage_id":3,"title":"Close this deal!","value":0}`,
+		},
+		{
+			Deal{Title: "Close this deal!", PersonID: 1, Value: 1000},
+			`{"org_id":0,"person_id":1,"title":"Close this deal!","value":1000}`,
+		},
+		{
+			Deal{Title: "Close this deal!", PersonID: 1, Value: 1250.5, Currency: "EUR"},
+			`{"currency":"EUR","org_id":0,"person_id":1,"title":"Close this deal!","value":1250.5}`,
+		},
+	}
+	for _, tc := range cases {
+		sent := map[string]string{}
+		client := NewClient("http://base", "abc123", ClientOptions{
+			HTTPClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/deals?api_token=abc123": fmt.Sprintf(dealUpdateResp, 1, 3),
+				},
+				sent: sent,
+			},
+		})
+
+		err := client.CreateDeal(&tc.deal)
+		if err != nil {
+			t.Errorf("Unexpected error creating deal: %+v", err)
+			continue
+		}
+
+		if actual := sent["http://base/v1/deals?api_token=abc123"]; actual != tc.expected {
+			t.Errorf("Create body want %s; got %s", tc.expected, actual)
+		}
+		if tc.deal.ID != 1 {
+			t.Errorf("Failed to create deal. Expected ID to be 1; got %d", tc.deal.ID)
+		}
+	}
+}
+
+func Test_CreateDeal_Validation(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{},
+	})
+
+	if err := client.CreateDeal(&Deal{PersonID: 1}); err == nil || err.Error() != "Deal requires a title" {
+		t.Errorf("Expected error creating deal with no title; got %+v", err)
+	}
+	if err := client.CreateDeal(&Deal{Title: "Close this deal!"}); err == nil {
+		t.Error("Expected error creating deal with no person or organization")
+	}
+	if err := client.CreateDeal(&Deal{Title: "Close this deal!", OrganizationID: 1}); err == nil || strings.Contains(err.Error(), "requires") {
+		t.Errorf("Expected deal with an organization to be sent; got %+v", err)
+	}
+}
+
+func Test_DefaultVisibleTo(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit":                        `{"success": true, "data": null}`,
+				"http://base/v1/organizations?api_token=abc123":                                             fmt.Sprintf(orgCreateResp, 2, "Videofruit"),
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, 1, "test@videofruit.com"),
+				"http://base/v1/deals?api_token=abc123":                                                     fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+			sent: sent,
+		},
+		DefaultVisibleTo: 3,
+	})
+
+	if err := client.FindOrCreateOrganization(&Organization{Name: "Videofruit"}); err != nil {
+		t.Errorf("Unexpected error creating organization: %+v", err)
+	}
+	if err := client.FindOrCreatePerson(&Person{Email: []string{"test@videofruit.com"}}); err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+	}
+	if err := client.CreateDeal(&Deal{Title: "Close this deal!", PersonID: 1}); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+	}
+
+	for _, url := range []string{
+		"http://base/v1/organizations?api_token=abc123",
+		"http://base/v1/persons?api_token=abc123",
+		"http://base/v1/deals?api_token=abc123",
+	} {
+		if !strings.Contains(sent[url], `"visible_to":3`) {
+			t.Errorf("Expected visible_to in create body for %s; got %s", url, sent[url])
+		}
+	}
+}
+
+func Test_CreatedAtOnCreate(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit":                        `{"success": true, "data": null}`,
+				"http://base/v1/organizations?api_token=abc123":                                             fmt.Sprintf(orgCreateResp, 2, "Videofruit"),
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+				"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, 1, "test@videofruit.com"),
+				"http://base/v1/deals?api_token=abc123":                                                     fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+			sent: sent,
+		},
+	})
+	createdAt := PipedriveTime{time.Date(2015, 3, 1, 9, 30, 0, 0, time.UTC)}
+
+	if err := client.FindOrCreateOrganization(&Organization{Name: "Videofruit", CreatedAt: createdAt}); err != nil {
+		t.Errorf("Unexpected error creating organization: %+v", err)
+	}
+	if err := client.FindOrCreatePerson(&Person{Email: []string{"test@videofruit.com"}, CreatedAt: createdAt}); err != nil {
+		t.Errorf("Unexpected error creating person: %+v", err)
+	}
+	if err := client.CreateDeal(&Deal{Title: "Close this deal!", PersonID: 1, CreatedAt: createdAt}); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+	}
+
+	for _, url := range []string{
+		"http://base/v1/organizations?api_token=abc123",
+		"http://base/v1/persons?api_token=abc123",
+		"http://base/v1/deals?api_token=abc123",
+	} {
+		if !strings.Contains(sent[url], `"add_time":"2015-03-01 09:30:00"`) {
+			t.Errorf("Expected add_time in create body for %s; got %s", url, sent[url])
+		}
+	}
+
+	if err := client.CreateDeal(&Deal{Title: "Close this deal!", PersonID: 1}); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+	}
+	if body := sent["http://base/v1/deals?api_token=abc123"]; strings.Contains(body, "add_time") {
+		t.Errorf("Expected no add_time in create body without CreatedAt; got %s", body)
+	}
+}
+
+func Test_CreateDeal_Result(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals?api_token=abc123": dealCreateResp,
+			},
+		},
+	})
+
+	fields := map[string]interface{}{"abc123hash": "Custom field value"}
+	deal := Deal{Title: "close this deal!", Value: 1000, PersonID: 1, Fields: fields}
+	if err := client.CreateDeal(&deal); err != nil {
+		t.Errorf("Unexpected error creating deal: %+v", err)
+		return
+	}
+
+	expected := Deal{
+		ID:             9,
+		Title:          "Close this deal!",
+		Value:          1000,
+		Currency:       "USD",
+		UserID:         3219426,
+		PersonID:       1,
+		OrganizationID: 0,
+		StageID:        1,
+		PipelineID:     1,
+		Status:         "open",
+		CreatedAt:      PipedriveTime{time.Date(2017, 11, 16, 20, 3, 54, 0, time.UTC)},
+		UpdatedAt:      PipedriveTime{time.Date(2017, 11, 16, 20, 3, 54, 0, time.UTC)},
+		Fields:         fields,
+		Owner:          &User{ID: 3219426, Name: "Chris Marshall", Email: "chris@videofruit.com", Active: true},
+	}
+	if !reflect.DeepEqual(deal, expected) {
+		t.Errorf("Deal want %+v; got %+v", expected, deal)
+	}
+}
+
+func Test_MoveDealToStage(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1?api_token=abc123": fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+		},
+	})
+
+	err := client.MoveDealToStage(1, 3)
+	if err != nil {
+		t.Errorf("Unexpected error moving deal: %+v", err)
+	}
+}
+
+func Test_MoveDealToStage_InvalidID(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{},
+	})
+
+	if err := client.MoveDealToStage(0, 3); err == nil {
+		t.Error("Expected error moving deal with no ID")
+	}
+	if err := client.MoveDealToStage(1, 0); err == nil {
+		t.Error("Expected error moving deal to stage with no ID")
+	}
+}
+
+func Test_SetDealStatus(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1?api_token=abc123": fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+			sent: sent,
+		},
+	})
+
+	if err := client.SetDealStatus(1, DealStatusOpen); err != nil {
+		t.Errorf("Unexpected error setting deal status: %+v", err)
+		return
+	}
+	expected := `{"status":"open"}`
+	if actual := sent["http://base/v1/deals/1?api_token=abc123"]; actual != expected {
+		t.Errorf("Update body want %s; got %s", expected, actual)
+	}
+
+	delete(sent, "http://base/v1/deals/1?api_token=abc123")
+	if err := client.SetDealStatus(1, "Won"); err == nil {
+		t.Error("Expected error setting an unknown deal status")
+	}
+	if _, ok := sent["http://base/v1/deals/1?api_token=abc123"]; ok {
+		t.Error("Expected no request for an unknown deal status")
+	}
+}
+
+func Test_MarkDealWon(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1?api_token=abc123": fmt.Sprintf(dealUpdateResp, 1, 3),
+			},
+			sent: sent,
+		},
+	})
+
+	err := client.MarkDealWon(1)
+	if err != nil {
+		t.Errorf("Unexpected error marking deal won: %+v", err)
+		return
+	}
+
+	expected := `{"status":"won"}`
+	if actual := sent["http://base/v1/deals/1?api_token=abc123"]; actual != expected {
+		t.Errorf("Update body want %s; got %s", expected, actual)
+	}
+}
+
+func Test_MarkDealLost(t *testing.T) {
+	cases := map[string]string{
+		"":         `{"status":"lost"}`,
+		"Too slow": `{"lost_reason":"Too slow","status":"lost"}`,
+	}
+	for reason, expected := range cases {
+		sent := map[string]string{}
+		client := NewClient("http://base", "abc123", ClientOptions{
+			HTTPClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/deals/1?api_token=abc123": fmt.Sprintf(dealUpdateResp, 1, 3),
+				},
+				sent: sent,
+			},
+		})
+
+		err := client.MarkDealLost(1, reason)
+		if err != nil {
+			t.Errorf("Unexpected error marking deal lost: %+v", err)
+			continue
+		}
+
+		if actual := sent["http://base/v1/deals/1?api_token=abc123"]; actual != expected {
+			t.Errorf("Update body want %s; got %s", expected, actual)
+		}
+	}
+}
+
+func Test_NonSuccessStatus(t *testing.T) {
+	errorPage := "<html><body>Internal Server Error</body></html>"
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": errorPage,
+				"http://base/v1/deals?api_token=abc123":                                                     errorPage,
+				"http://base/v1/deals/1?api_token=abc123":                                                   errorPage,
+				"http://base/v1/pipelines?api_token=abc123":                                                 errorPage,
+			},
+			statuses: map[string]int{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": http.StatusInternalServerError,
+				"http://base/v1/deals?api_token=abc123":                                                     http.StatusInternalServerError,
+				"http://base/v1/deals/1?api_token=abc123":                                                   http.StatusInternalServerError,
+				"http://base/v1/pipelines?api_token=abc123":                                                 http.StatusInternalServerError,
+			},
+		},
+	})
+
+	calls := map[string]func() error{
+		"FindOrCreatePerson": func() error {
+			return client.FindOrCreatePerson(&Person{Email: []string{"test@videofruit.com"}})
+		},
+		"CreateDeal": func() error {
+			return client.CreateDeal(&Deal{Title: "Close this deal!", PersonID: 1})
+		},
+		"MarkDealWon": func() error {
+			return client.MarkDealWon(1)
+		},
+		"ListPipelines": func() error {
+			_, err := client.ListPipelines()
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			t.Errorf("%s: expected *APIError; got %+v", name, err)
+			continue
+		}
+		if apiErr.StatusCode != http.StatusInternalServerError || apiErr.Body != errorPage {
+			t.Errorf("%s: unexpected API error: %+v", name, apiErr)
+		}
+	}
+}
+
+func Test_ResponseBodiesClosed(t *testing.T) {
+	bodies := []*trackedBody{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: trackingClient{
+			fakeClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+					"http://base/v1/persons?api_token=abc123":                                                   fmt.Sprintf(personCreateResp, 1, "test@videofruit.com"),
+					"http://base/v1/deals/1?api_token=abc123":                                                   fmt.Sprintf(dealUpdateResp, 1, 3),
+				},
+			},
+			bodies: &bodies,
+		},
+	})
+
+	if err := client.FindOrCreatePerson(&Person{Email: []string{"test@videofruit.com"}}); err != nil {
+		t.Errorf("Unexpected error finding or creating person: %+v", err)
+	}
+	if err := client.MarkDealWon(1); err != nil {
+		t.Errorf("Unexpected error marking deal won: %+v", err)
+	}
+
+	if len(bodies) != 3 {
+		t.Errorf("Expected 3 responses; got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if !body.closed {
+			t.Errorf("Response body %d was not closed", i)
+		}
+	}
+}
+
+func Test_FindOrCreateOrganization_ExactMatch(t *testing.T) {
+	for _, exact := range []bool{false, true} {
+		client := NewClient("http://base", "abc123", ClientOptions{
+			HTTPClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/organizations/find?api_token=abc123&term=Acme": fmt.Sprintf(orgFindResp, 1, "Acme Corp"),
+					"http://base/v1/organizations?api_token=abc123":                fmt.Sprintf(orgCreateResp, 2, "Acme"),
+				},
+			},
+			ExactOrganizationMatch: exact,
+		})
+
+		org := Organization{Name: "Acme"}
+		if err := client.FindOrCreateOrganization(&org); err != nil {
+			t.Errorf("Unexpected error finding or creating organization: %+v", err)
+			continue
+		}
+
+		expectedID := 1
+		if exact {
+			expectedID = 2
+		}
+		if org.ID != expectedID {
+			t.Errorf("Exact match %t: expected ID to be %d; got %d", exact, expectedID, org.ID)
+		}
+	}
+}
+
+func Test_FindOrganization(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/organizations/find?api_token=abc123&term=Videofruit": fmt.Sprintf(orgFindResp, 1, "Videofruit"),
+				"http://base/v1/organizations/find?api_token=abc123&term=Acme":       fmt.Sprintf(orgFindResp, 2, "Acme Corp"),
+				"http://base/v1/organizations/find?api_token=abc123&term=Missing":    `{"success": true, "data": null}`,
+			},
+		},
+		ExactOrganizationMatch: true,
+	})
+
+	org, err := client.FindOrganization("Videofruit")
+	if err != nil {
+		t.Errorf("Unexpected error finding organization: %+v", err)
+		return
+	}
+	if org.ID != 1 || org.Name != "Videofruit" {
+		t.Errorf("Unexpected organization: %+v", org)
+	}
+
+	for _, name := range []string{"Acme", "Missing"} {
+		if _, err = client.FindOrganization(name); err != ErrNotFound {
+			t.Errorf("Expected ErrNotFound finding %s; got %+v", name, err)
+		}
+	}
+}
+
+func Test_UserAgent(t *testing.T) {
+	for _, userAgent := range []string{"", "acme-sync/1.0"} {
+		headers := map[string]http.Header{}
+		client := NewClient("http://base", "abc123", ClientOptions{
+			HTTPClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/pipelines?api_token=abc123": pipelinesResp,
+					"http://base/v1/deals/1?api_token=abc123":   fmt.Sprintf(dealUpdateResp, 1, 3),
+				},
+				headers: headers,
+			},
+			UserAgent: userAgent,
+		})
+
+		if _, err := client.ListPipelines(); err != nil {
+			t.Errorf("Unexpected error listing pipelines: %+v", err)
+		}
+		if err := client.MarkDealWon(1); err != nil {
+			t.Errorf("Unexpected error marking deal won: %+v", err)
+		}
+
+		expected := userAgent
+		if expected == "" {
+			expected = DefaultUserAgent
+		}
+		for url, header := range headers {
+			if actual := header.Get("User-Agent"); actual != expected {
+				t.Errorf("User-Agent for %s want %s; got %s", url, expected, actual)
+			}
+		}
+		if len(headers) != 2 {
+			t.Errorf("Expected 2 requests; got %d", len(headers))
+		}
+	}
+}
+
+func Test_UserAgentClientLiteral(t *testing.T) {
+	headers := map[string]http.Header{}
+	client := &Client{
+		BaseURL:  "http://base/v1",
+		APIToken: "abc123",
+		httpClient: fakeClient{
+			reqs:    map[string]string{"http://base/v1/pipelines?api_token=abc123": pipelinesResp},
+			headers: headers,
+		},
+	}
+
+	if _, err := client.ListPipelines(); err != nil {
+		t.Fatalf("Unexpected error listing pipelines: %+v", err)
+	}
+	for url, header := range headers {
+		if actual := header.Get("User-Agent"); actual != DefaultUserAgent {
+			t.Errorf("User-Agent for %s want %s; got %s", url, DefaultUserAgent, actual)
+		}
+	}
+	if len(headers) != 1 {
+		t.Errorf("Expected 1 request; got %d", len(headers))
+	}
+}
+
+func Test_WithRequestID(t *testing.T) {
+	headers := map[string]http.Header{}
+	newClient := func(header string) *Client {
+		return NewClient("http://base", "abc123", ClientOptions{
+			HTTPClient: fakeClient{
+				reqs: map[string]string{
+					"http://base/v1/pipelines?api_token=abc123": pipelinesResp,
+				},
+				headers: headers,
+			},
+			RequestIDHeader: header,
+		})
+	}
+
+	client := newClient("")
+	if _, err := client.WithRequestID("req-1").ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+		return
+	}
+	if actual := headers["http://base/v1/pipelines?api_token=abc123"].Get("X-Request-ID"); actual != "req-1" {
+		t.Errorf("Expected request ID req-1; got %q", actual)
+	}
+
+	if _, err := client.ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+		return
+	}
+	if actual := headers["http://base/v1/pipelines?api_token=abc123"].Get("X-Request-ID"); actual != "" {
+		t.Errorf("Expected no request ID on the original client; got %q", actual)
+	}
+
+	if _, err := newClient("X-Correlation-ID").WithRequestID("req-2").ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+		return
+	}
+	if actual := headers["http://base/v1/pipelines?api_token=abc123"].Get("X-Correlation-ID"); actual != "req-2" {
+		t.Errorf("Expected request ID req-2; got %q", actual)
+	}
+}
+
+func Test_Transport(t *testing.T) {
+	transport := &fakeTransport{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		Transport: transport,
+	})
+
+	if _, err := client.ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+	}
+	if len(transport.urls) != 1 || transport.urls[0] != "http://base/v1/pipelines?api_token=abc123" {
+		t.Errorf("Expected the request to go through the transport; got %v", transport.urls)
+	}
+}
+
+// fakeTransport is a RoundTripper answering every request with pipelinesResp
+type fakeTransport struct {
+	urls []string
+}
+
+func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.urls = append(t.urls, req.URL.String())
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(pipelinesResp)),
+		Request:    req,
+	}, nil
+}
+
+func Test_HTMLResponse(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/pipelines?api_token=abc123": "\n<!DOCTYPE html>\n<html><head><title>Log in</title></head><body></body></html>",
+			},
+		},
+	})
+
+	_, err := client.ListPipelines()
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Errorf("Expected *APIError; got %+v", err)
+		return
+	}
+	if apiErr.StatusCode != http.StatusOK || !strings.Contains(apiErr.Error(), "HTML") {
+		t.Errorf("Expected error explaining the HTML response; got %+v", apiErr)
+	}
+}
+
+func Test_Gzip(t *testing.T) {
+	transport := &gzipTransport{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		Transport: transport,
+	})
+
+	pipelines, err := client.ListPipelines()
+	if err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+		return
+	}
+	if len(pipelines) == 0 {
+		t.Error("Expected pipelines from the gzipped response")
+	}
+	if transport.acceptEncoding != "gzip" {
+		t.Errorf("Expected gzip to be accepted; got %q", transport.acceptEncoding)
+	}
+}
+
+func Test_MaxResponseBytes(t *testing.T) {
+	reqs := map[string]string{"http://base/v1/pipelines?api_token=abc123": pipelinesResp}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient:       fakeClient{reqs: reqs},
+		MaxResponseBytes: 64,
+	})
+	if _, err := client.ListPipelines(); err == nil {
+		t.Error("Expected error reading a response over the limit")
+	}
+
+	client = NewClient("http://base", "abc123", ClientOptions{HTTPClient: fakeClient{reqs: reqs}})
+	if limit := client.responseLimit(); limit != DefaultMaxResponseBytes {
+		t.Errorf("Expected default limit %d; got %d", DefaultMaxResponseBytes, limit)
+	}
+	if _, err := client.ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+	}
+	if limit := (&Client{}).responseLimit(); limit != DefaultMaxResponseBytes {
+		t.Errorf("Expected default limit %d for a struct literal; got %d", DefaultMaxResponseBytes, limit)
+	}
+
+	client = NewClient("http://base", "abc123", ClientOptions{HTTPClient: fakeClient{reqs: reqs}, MaxResponseBytes: -1})
+	if limit := client.responseLimit(); limit >= 0 {
+		t.Errorf("Expected no limit; got %d", limit)
+	}
+	if _, err := client.ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+	}
+}
+
+// gzipTransport is a RoundTripper answering every request with a gzipped
+// pipelinesResp
+type gzipTransport struct {
+	acceptEncoding string
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.acceptEncoding = req.Header.Get("Accept-Encoding")
+
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	gz.Write([]byte(pipelinesResp))
+	gz.Close()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:       ioutil.NopCloser(buf),
+		Request:    req,
+	}, nil
+}
+
+func Test_safeCall(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/pipelines?api_token=abc123": pipelinesResp,
 			},
 		},
 	})
 
-	org := Organization{Name: name}
+	var out []panickyPipeline
+	_, err := client.getEntity("/pipelines", &out)
+	if err == nil || !strings.Contains(err.Error(), "Recovered from panic") {
+		t.Errorf("Expected recovered panic error; got %+v", err)
+	}
 
-	err := client.FindOrCreateOrganization(&org)
-	if err != nil {
-		t.Errorf("Unexpected error finding organization: %+v", err)
-		return
+	client = NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/notes?api_token=abc123&start=0": notesResp,
+			},
+		},
+	})
+	err = client.getAllPages("/notes", func(data json.RawMessage) error {
+		var page []panickyPipeline
+		return json.Unmarshal(data, &page)
+	})
+	if err == nil || !strings.Contains(err.Error(), "Recovered from panic") {
+		t.Errorf("Expected recovered panic error from a page; got %+v", err)
 	}
 
-	if org.ID != expectedID {
-		t.Errorf("Failed to find Organization. Expected ID to be %d; got %d", expectedID, org.ID)
-		return
+	if err := safeCall(func() error { return nil }); err != nil {
+		t.Errorf("Unexpected error from safeCall: %+v", err)
 	}
 }
-func Test_FindOrCreateOrganization_NotFound(t *testing.T) {
-	expectedID := 2
-	name := "Videofruit"
+
+// panickyPipeline fails to decode the way an unchecked type assertion would
+type panickyPipeline struct{}
+
+func (p *panickyPipeline) UnmarshalJSON(b []byte) error {
+	var data interface{}
+	json.Unmarshal(b, &data)
+	_ = data.([]interface{})
+	return nil
+}
+
+func Test_WithBaseURL(t *testing.T) {
 	client := NewClient("http://base", "abc123", ClientOptions{
 		HTTPClient: fakeClient{
 			reqs: map[string]string{
-				"http://base/organizations/find?api_token=abc123&term=Videofruit": `{ "success": true, "data": null, "additional_data": { "pagination": { "start": 0, "limit": 100, "more_items_in_collection": false } } }`,
-				"http://base/organizations?api_token=abc123":                      fmt.Sprintf(orgCreateResp, expectedID, name),
+				"http://base/v1/pipelines?api_token=abc123":    pipelinesResp,
+				"http://sandbox/v1/pipelines?api_token=abc123": pipelinesResp,
 			},
 		},
 	})
 
-	org := Organization{Name: name}
+	sandbox := client.WithBaseURL("http://sandbox/")
+	if sandbox == client || sandbox.BaseURL != "http://sandbox" || client.BaseURL != "http://base" {
+		t.Errorf("Expected a separate client for the sandbox; got %s and %s", client.BaseURL, sandbox.BaseURL)
+	}
+	if sandbox.idempotency == client.idempotency {
+		t.Error("Expected the sandbox client not to share idempotency keys")
+	}
+	for _, c := range []*Client{client, sandbox} {
+		if _, err := c.ListPipelines(); err != nil {
+			t.Errorf("Unexpected error listing pipelines on %s: %+v", c.BaseURL, err)
+		}
+	}
+}
 
-	err := client.FindOrCreateOrganization(&org)
-	if err != nil {
-		t.Errorf("Unexpected error finding or creating organization: %+v", err)
-		return
+func Test_SetAPIToken(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/pipelines?api_token=abc123": pipelinesResp,
+				"http://base/v1/pipelines?api_token=def456": pipelinesResp,
+			},
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ListPipelines(); err != nil {
+				t.Errorf("Unexpected error listing pipelines: %+v", err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				client.SetAPIToken("def456")
+			} else {
+				client.WithBaseURL("http://sandbox")
+			}
+		}(i)
 	}
+	wg.Wait()
 
-	if org.ID != expectedID {
-		t.Errorf("Failed to create Organization. Expected ID to be %d; got %d", expectedID, org.ID)
-		return
+	url, err := client.authenticatedURL("/pipelines")
+	if err != nil || url.String() != "http://base/v1/pipelines?api_token=def456" {
+		t.Errorf("Expected requests to use the new token; got %s (%+v)", url, err)
+	}
+}
+
+func Test_LastResponseHeaders(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/pipelines?api_token=abc123": pipelinesResp,
+				"http://base/v1/deals/1?api_token=abc123":   `{"success": false, "error": "Deal not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/deals/1?api_token=abc123": http.StatusNotFound,
+			},
+			respHeaders: map[string]http.Header{
+				"http://base/v1/pipelines?api_token=abc123": {"X-Request-Id": {"req-1"}},
+				"http://base/v1/deals/1?api_token=abc123":   {"X-Request-Id": {"req-2"}},
+			},
+		},
+	})
+
+	if client.LastResponseHeaders() != nil {
+		t.Error("Expected no headers before any request")
+	}
+	if _, err := client.ListPipelines(); err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+	}
+	if id := client.LastResponseHeaders().Get("X-Request-Id"); id != "req-1" {
+		t.Errorf("Expected request ID req-1; got %q", id)
+	}
+
+	err := client.DeleteDeal(1)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Header.Get("X-Request-Id") != "req-2" {
+		t.Errorf("Expected API error with request ID req-2; got %+v", err)
+	}
+	if id := client.LastResponseHeaders().Get("X-Request-Id"); id != "req-2" {
+		t.Errorf("Expected request ID req-2; got %q", id)
+	}
+}
+
+func Test_WithTimeout(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{})
+	slow := client.WithTimeout(time.Minute)
+
+	if timeout := slow.httpClient.(*http.Client).Timeout; timeout != time.Minute {
+		t.Errorf("Expected copy to time out after a minute; got %s", timeout)
+	}
+	if timeout := client.httpClient.(*http.Client).Timeout; timeout != 10*time.Second {
+		t.Errorf("Expected original client to keep its timeout; got %s", timeout)
+	}
+	if slow.fieldCache != client.fieldCache || slow.idempotency != client.idempotency {
+		t.Error("Expected the copy to share the client's caches")
+	}
+
+	fake := fakeClient{}
+	custom := NewClient("http://base", "abc123", ClientOptions{HTTPClient: fake}).WithTimeout(time.Minute)
+	if _, ok := custom.httpClient.(fakeClient); !ok {
+		t.Errorf("Expected custom HTTP client to be kept; got %T", custom.httpClient)
+	}
+}
+
+func Test_ClientLiteral(t *testing.T) {
+	client := &Client{BaseURL: "http://127.0.0.1:0", APIToken: "abc123", MaxRetries: 1}
+	if client.requestor() != defaultHTTPClient {
+		t.Errorf("Expected the default HTTP client; got %T", client.requestor())
+	}
+	if _, err := client.ListPipelines(); err == nil {
+		t.Error("Expected error reaching an invalid address")
+	}
+
+	slow := client.WithTimeout(time.Minute)
+	if timeout := slow.httpClient.(*http.Client).Timeout; timeout != time.Minute {
+		t.Errorf("Expected copy to time out after a minute; got %s", timeout)
 	}
 }
 
@@ -113,7 +1270,7 @@ func Test_authenticatedURLNoParams(t *testing.T) {
 	path := "/organizations"
 	token := "abc123"
 	client := NewClient(base, token, ClientOptions{})
-	expected := base + path + "?api_token=" + token
+	expected := base + "/v1" + path + "?api_token=" + token
 	actual, err := client.authenticatedURL(path)
 	if err != nil {
 		t.Error(err)
@@ -129,7 +1286,7 @@ func Test_authenticatedURLExistingParams(t *testing.T) {
 	path := "/organizations"
 	token := "abc123"
 	client := NewClient(base, token, ClientOptions{})
-	expected := base + path + "?api_token=" + token + "&" + param
+	expected := base + "/v1" + path + "?api_token=" + token + "&" + param
 	actual, err := client.authenticatedURL(path + "?" + param)
 	if err != nil {
 		t.Error(err)
@@ -139,28 +1296,244 @@ func Test_authenticatedURLExistingParams(t *testing.T) {
 	}
 }
 
+func Test_authenticatedURLTrailingSlash(t *testing.T) {
+	client := NewClient("http://base/", "abc123", ClientOptions{})
+	expected := "http://base/v1/persons?api_token=abc123"
+	actual, err := client.authenticatedURL("/persons")
+	if err != nil {
+		t.Error(err)
+	}
+	if actual.String() != expected {
+		t.Errorf("Authenticated URL want %s; got %s", expected, actual)
+	}
+}
+
+func Test_authenticatedURLVersionedTrailingSlash(t *testing.T) {
+	client := NewClient("http://base/v1/", "abc123", ClientOptions{})
+	expected := "http://base/v1/persons?api_token=abc123"
+	actual, err := client.authenticatedURL("/persons")
+	if err != nil {
+		t.Error(err)
+	}
+	if actual.String() != expected {
+		t.Errorf("Authenticated URL want %s; got %s", expected, actual)
+	}
+}
+
+func Test_authenticatedURLAPIVersion(t *testing.T) {
+	cases := []struct {
+		base     string
+		version  string
+		expected string
+	}{
+		{"http://base", "", "http://base/v1/persons?api_token=abc123"},
+		{"http://base", "api/v2", "http://base/api/v2/persons?api_token=abc123"},
+		{"http://base/v1", "", "http://base/v1/persons?api_token=abc123"},
+		{"http://base/v1/", "", "http://base/v1/persons?api_token=abc123"},
+		{"http://base/api/v2", "v1", "http://base/api/v2/persons?api_token=abc123"},
+	}
+	for _, tc := range cases {
+		client := NewClient(tc.base, "abc123", ClientOptions{APIVersion: tc.version})
+		actual, err := client.authenticatedURL("/persons")
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual.String() != tc.expected {
+			t.Errorf("Authenticated URL want %s; got %s", tc.expected, actual)
+		}
+	}
+}
+
+func Test_authenticatedURLInvalidBase(t *testing.T) {
+	for _, base := range []string{"", "base", "/v1"} {
+		client := NewClient(base, "abc123", ClientOptions{})
+		if _, err := client.authenticatedURL("/persons"); err == nil {
+			t.Errorf("Expected error with base URL %q", base)
+		}
+	}
+}
+
+func Test_DryRun(t *testing.T) {
+	email := "test@videofruit.com"
+	sent := map[string]string{}
+	logger := &recordingLogger{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+			},
+			sent: sent,
+		},
+		DryRun: true,
+		Logger: logger,
+	})
+
+	person := Person{Name: "Test", Email: []string{email}}
+	created, err := client.FindOrCreatePersonEx(&person)
+	if err != nil || !created {
+		t.Errorf("Expected dry run create to succeed; got %v (%+v)", created, err)
+		return
+	}
+	if err = client.DeleteDeal(1); err != nil {
+		t.Errorf("Expected dry run delete to succeed; got %+v", err)
+	}
+
+	if len(sent) != 0 {
+		t.Errorf("Expected no requests with a body to be sent; got %+v", sent)
+	}
+	if len(logger.lines) != 2 {
+		t.Errorf("Expected 2 dry run log lines; got %+v", logger.lines)
+		return
+	}
+	if !strings.Contains(logger.lines[0], "POST /persons") || !strings.Contains(logger.lines[0], email) {
+		t.Errorf("Expected create to be logged; got %s", logger.lines[0])
+	}
+	if strings.Contains(logger.lines[0], "abc123") {
+		t.Errorf("Expected API token to be left out of the log; got %s", logger.lines[0])
+	}
+	if !strings.Contains(logger.lines[1], "DELETE /deals/1") {
+		t.Errorf("Expected delete to be logged; got %s", logger.lines[1])
+	}
+}
+
+func Test_DryRun_CreatePersonWithDeal(t *testing.T) {
+	logger := &recordingLogger{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": personNoFindResp,
+			},
+		},
+		DryRun: true,
+		Logger: logger,
+	})
+
+	person := Person{Name: "Test", Email: []string{"test@videofruit.com"}}
+	deal := Deal{Title: "Close this deal!"}
+	if err := client.CreatePersonWithDeal(&person, &deal); err != nil {
+		t.Errorf("Expected dry run to succeed; got %+v", err)
+		return
+	}
+	if len(logger.lines) != 2 || !strings.Contains(logger.lines[0], "POST /persons") || !strings.Contains(logger.lines[1], "Close this deal!") {
+		t.Errorf("Expected person and deal creation to be logged; got %+v", logger.lines)
+	}
+}
+
+func Test_OnRequest(t *testing.T) {
+	var stats []RequestStats
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/persons/find?api_token=abc123&search_by_email=1&term=test%40videofruit.com": fmt.Sprintf(personFindResp, 1, "test@videofruit.com"),
+				"http://base/v1/deals/1?api_token=abc123":                                                   `{"success": false, "error": "Deal not found"}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/deals/1?api_token=abc123": http.StatusNotFound,
+			},
+		},
+		OnRequest: func(s RequestStats) {
+			stats = append(stats, s)
+		},
+	})
+
+	client.FindPerson("test@videofruit.com")
+	client.DeleteDeal(1)
+	client.DeleteDeal(0)
+
+	if len(stats) != 2 {
+		t.Errorf("Expected stats for 2 requests; got %+v", stats)
+		return
+	}
+	if stats[0].Method != http.MethodGet || stats[0].Path != "/persons/find" || stats[0].StatusCode != http.StatusOK {
+		t.Errorf("Unexpected stats for find: %+v", stats[0])
+	}
+	if stats[1].Method != http.MethodDelete || stats[1].Path != "/deals/1" || stats[1].StatusCode != http.StatusNotFound {
+		t.Errorf("Unexpected stats for delete: %+v", stats[1])
+	}
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
 type fakeClient struct {
 	reqs map[string]string
+	// statuses overrides the 200 status code returned for a URL
+	statuses map[string]int
+	// sent records the request body for each URL when non-nil
+	sent map[string]string
+	// headers records the request headers for each URL when non-nil
+	headers map[string]http.Header
+	// respHeaders sets the response headers returned for a URL
+	respHeaders map[string]http.Header
 }
 
-func (c fakeClient) Get(url string) (*http.Response, error) {
+func (c fakeClient) Do(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+	c.record(url, req.Body)
+	if c.headers != nil {
+		c.headers[url] = req.Header
+	}
 	if body, ok := c.reqs[url]; ok {
-		return &http.Response{
-			Body: ioutil.NopCloser(strings.NewReader(body)),
-		}, nil
+		return c.response(url, body), nil
 	}
 
-	return nil, fmt.Errorf("URL not mocked out: %s", url)
+	return nil, fmt.Errorf("URL not mocked out: %s %s", req.Method, url)
 }
 
-func (c fakeClient) Post(url, contentType string, body io.Reader) (*http.Response, error) {
-	if body, ok := c.reqs[url]; ok {
-		return &http.Response{
-			Body: ioutil.NopCloser(strings.NewReader(body)),
-		}, nil
+func (c fakeClient) response(url, body string) *http.Response {
+	status := http.StatusOK
+	if code, ok := c.statuses[url]; ok {
+		status = code
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     c.respHeaders[url],
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func (c fakeClient) record(url string, body io.Reader) {
+	if c.sent == nil || body == nil {
+		return
+	}
+	b, _ := ioutil.ReadAll(body)
+	c.sent[url] = string(b)
+}
+
+// trackingClient wraps fakeClient, recording whether response bodies get closed
+type trackingClient struct {
+	fakeClient
+	bodies *[]*trackedBody
+}
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func (c trackingClient) track(resp *http.Response, err error) (*http.Response, error) {
+	if resp != nil {
+		body := &trackedBody{Reader: resp.Body}
+		*c.bodies = append(*c.bodies, body)
+		resp.Body = body
 	}
+	return resp, err
+}
 
-	return nil, fmt.Errorf("URL not mocked out: %s", url)
+func (c trackingClient) Do(req *http.Request) (*http.Response, error) {
+	return c.track(c.fakeClient.Do(req))
 }
 
 const orgFindResp = `{
@@ -180,6 +1553,28 @@ const orgFindResp = `{
 		}
 	}
 }`
+const orgsFindResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"name": "Acme Corp",
+			"visible_to": "3"
+		},
+		{
+			"id": 2,
+			"name": "Acme",
+			"visible_to": "3"
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
 const orgCreateResp = `{
 	"success": true,
 	"data": {
@@ -279,6 +1674,38 @@ const personFindResp = `{
 	}
 }`
 
+const personsByNameResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"name": "Tester McTest",
+			"email": "test@videofruit.com",
+			"phone": "555-0100",
+			"org_id": null,
+			"org_name": "",
+			"visible_to": "3"
+		},
+		{
+			"id": 2,
+			"name": "Tester McTest",
+			"email": "",
+			"phone": "",
+			"org_id": 1,
+			"org_name": "Videofruit",
+			"visible_to": "3"
+		}
+	],
+	"additional_data": {
+		"search_method": "search_by_name",
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
 const personNoFindResp = `{
 	"success": true,
 	"data": null,
@@ -390,3 +1817,52 @@ const personCreateResp = `{
 		}
 	}
 }`
+
+const dealUpdateResp = `{
+	"success": true,
+	"data": {
+		"id": %d,
+		"title": "Close this deal!",
+		"value": 1000,
+		"currency": "USD",
+		"stage_id": %d,
+		"status": "open",
+		"add_time": "2017-11-16 20:03:54",
+		"update_time": "2017-11-17 09:12:01"
+	}
+}`
+
+const dealCreateResp = `{
+	"success": true,
+	"data": {
+		"id": 9,
+		"creator_user_id": {
+			"id": 3219426,
+			"name": "Chris Marshall",
+			"value": 3219426
+		},
+		"user_id": {
+			"id": 3219426,
+			"name": "Chris Marshall",
+			"email": "chris@videofruit.com",
+			"active_flag": true,
+			"value": 3219426
+		},
+		"person_id": {
+			"name": "Tester McTest",
+			"value": 1
+		},
+		"org_id": null,
+		"stage_id": 1,
+		"title": "Close this deal!",
+		"value": 1000,
+		"currency": "USD",
+		"add_time": "2017-11-16 20:03:54",
+		"update_time": "2017-11-16 20:03:54",
+		"active": true,
+		"deleted": false,
+		"status": "open",
+		"pipeline_id": 1,
+		"abc123hash": "Custom field value"
+	}
+}`
@@ -0,0 +1,39 @@
+package pipedrive
+
+import (
+	"fmt"
+)
+
+// Pipeline is a PipeDrive Pipeline representation
+type Pipeline struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Stage is a PipeDrive Stage representation
+type Stage struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	PipelineID int    `json:"pipeline_id"`
+	OrderNr    int    `json:"order_nr"`
+}
+
+// ListPipelines returns all Pipelines
+func (c *Client) ListPipelines() ([]Pipeline, error) {
+	var pipelines []Pipeline
+	_, err := c.getEntity("/pipelines", &pipelines)
+	return pipelines, err
+}
+
+// ListStages returns the Stages of a Pipeline. A pipelineID of 0 returns the
+// Stages of all Pipelines.
+func (c *Client) ListStages(pipelineID int) ([]Stage, error) {
+	path := "/stages"
+	if pipelineID != 0 {
+		path += fmt.Sprintf("?pipeline_id=%d", pipelineID)
+	}
+
+	var stages []Stage
+	_, err := c.getEntity(path, &stages)
+	return stages, err
+}
@@ -0,0 +1,123 @@
+package pipedrive
+
+import (
+	"testing"
+)
+
+func Test_ListPipelines(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/pipelines?api_token=abc123": pipelinesResp,
+			},
+		},
+	})
+
+	pipelines, err := client.ListPipelines()
+	if err != nil {
+		t.Errorf("Unexpected error listing pipelines: %+v", err)
+		return
+	}
+
+	if len(pipelines) != 2 {
+		t.Errorf("Expected 2 pipelines; got %d", len(pipelines))
+		return
+	}
+	if pipelines[1].ID != 2 || pipelines[1].Name != "Renewals" {
+		t.Errorf("Unexpected pipeline: %+v", pipelines[1])
+	}
+}
+
+func Test_ListStages(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/stages?api_token=abc123&pipeline_id=1": stagesResp,
+				"http://base/v1/stages?api_token=abc123":               stagesResp,
+			},
+		},
+	})
+
+	for _, pipelineID := range []int{0, 1} {
+		stages, err := client.ListStages(pipelineID)
+		if err != nil {
+			t.Errorf("Unexpected error listing stages: %+v", err)
+			continue
+		}
+
+		if len(stages) != 2 {
+			t.Errorf("Expected 2 stages; got %d", len(stages))
+			continue
+		}
+		expected := Stage{ID: 3, Name: "Qualified", PipelineID: 1, OrderNr: 2}
+		if stages[1] != expected {
+			t.Errorf("Stage want %+v; got %+v", expected, stages[1])
+		}
+	}
+}
+
+func Test_ListStages_NullData(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/stages?api_token=abc123&pipeline_id=9": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	stages, err := client.ListStages(9)
+	if err != nil {
+		t.Errorf("Unexpected error listing stages: %+v", err)
+	}
+	if len(stages) != 0 {
+		t.Errorf("Expected no stages; got %d", len(stages))
+	}
+}
+
+const pipelinesResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"name": "Sales",
+			"url_title": "sales",
+			"order_nr": 0,
+			"active": true,
+			"add_time": "2017-11-14 17:19:21",
+			"update_time": null
+		},
+		{
+			"id": 2,
+			"name": "Renewals",
+			"url_title": "renewals",
+			"order_nr": 1,
+			"active": true,
+			"add_time": "2017-11-14 17:19:21",
+			"update_time": null
+		}
+	]
+}`
+
+const stagesResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 1,
+			"order_nr": 1,
+			"name": "Lead In",
+			"active_flag": true,
+			"deal_probability": 100,
+			"pipeline_id": 1,
+			"pipeline_name": "Sales"
+		},
+		{
+			"id": 3,
+			"order_nr": 2,
+			"name": "Qualified",
+			"active_flag": true,
+			"deal_probability": 100,
+			"pipeline_id": 1,
+			"pipeline_name": "Sales"
+		}
+	]
+}`
@@ -0,0 +1,172 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Product is a PipeDrive Product representation. PipeDrive keeps a price per
+// currency; Price and Currency hold the first of them.
+type Product struct {
+	ID       int                    `json:"id"`
+	Name     string                 `json:"name"`
+	Code     string                 `json:"code"`
+	Price    float64                `json:"-"`
+	Currency string                 `json:"-"`
+	Fields   map[string]interface{} `json:"fields"`
+}
+
+// productPrice is one entry of a Product's prices
+type productPrice struct {
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+}
+
+// UnmarshalJSON decodes a Product, taking Price and Currency from the first of
+// its prices
+func (p *Product) UnmarshalJSON(b []byte) error {
+	type product Product
+	aux := struct {
+		*product
+		Prices []productPrice `json:"prices"`
+	}{product: (*product)(p)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.Prices) > 0 {
+		p.Price = aux.Prices[0].Price
+		p.Currency = aux.Prices[0].Currency
+	}
+	return nil
+}
+
+// DealProduct is a Product attached to a Deal
+type DealProduct struct {
+	ID        int     `json:"id"`
+	ProductID int     `json:"product_id"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	ItemPrice float64 `json:"item_price"`
+	Sum       float64 `json:"sum"`
+}
+
+// UnmarshalJSON decodes a DealProduct, falling back to the nested product for
+// its name
+func (dp *DealProduct) UnmarshalJSON(b []byte) error {
+	type dealProduct DealProduct
+	aux := struct {
+		*dealProduct
+		Product *struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"product"`
+	}{dealProduct: (*dealProduct)(dp)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if aux.Product != nil {
+		if dp.Name == "" {
+			dp.Name = aux.Product.Name
+		}
+		if dp.ProductID == 0 {
+			dp.ProductID = aux.Product.ID
+		}
+	}
+	return nil
+}
+
+// CreateProduct creates a new Product from the initialized Product. A Price
+// requires a Currency.
+func (c *Client) CreateProduct(p *Product) error {
+	if p.Name == "" {
+		return errors.New("Product requires a name")
+	}
+	if p.Price != 0 && p.Currency == "" {
+		return errors.New("Product price requires a currency")
+	}
+
+	bodyData := map[string]interface{}{
+		"name": p.Name,
+	}
+	if p.Code != "" {
+		bodyData["code"] = p.Code
+	}
+	if p.Currency != "" {
+		bodyData["prices"] = []productPrice{{Price: p.Price, Currency: p.Currency}}
+	}
+	for name, value := range p.Fields {
+		bodyData[name] = value
+	}
+
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := c.post("/products", bodyData, &created); err != nil {
+		return err
+	}
+
+	p.ID = created.ID
+	return nil
+}
+
+// ListProducts returns a page of Products starting at start. A limit of 0 uses
+// the API's default page size. The returned Pagination tells where the next
+// page starts.
+func (c *Client) ListProducts(start, limit int) ([]Product, Pagination, error) {
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(start))
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var products []Product
+	resp, err := c.getEntity("/products?"+query.Encode(), &products)
+	return products, resp.AdditionalData.Pagination, err
+}
+
+// AddDealProduct attaches a Product to a Deal and returns the ID of the
+// attachment
+func (c *Client) AddDealProduct(dealID, productID int, quantity int, price float64) (int, error) {
+	if dealID < 1 {
+		return 0, errors.New("Deal ID must be positive")
+	}
+	if productID < 1 {
+		return 0, errors.New("Product ID must be positive")
+	}
+	if quantity < 1 {
+		return 0, errors.New("Quantity must be at least 1")
+	}
+
+	var attached struct {
+		ID int `json:"id"`
+	}
+	err := c.post(fmt.Sprintf("/deals/%d/products", dealID), map[string]interface{}{
+		"product_id": productID,
+		"item_price": price,
+		"quantity":   quantity,
+	}, &attached)
+	return attached.ID, err
+}
+
+// ListDealProducts returns the Products attached to a Deal
+func (c *Client) ListDealProducts(dealID int) ([]DealProduct, error) {
+	if dealID < 1 {
+		return nil, errors.New("Deal ID must be positive")
+	}
+
+	var products []DealProduct
+	err := c.getAllPages(fmt.Sprintf("/deals/%d/products", dealID), func(data json.RawMessage) error {
+		var page []DealProduct
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		products = append(products, page...)
+		return nil
+	})
+	return products, err
+}
@@ -0,0 +1,210 @@
+package pipedrive
+
+import (
+	"testing"
+)
+
+func Test_CreateProduct(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/products?api_token=abc123": `{"success": true, "data": {"id": 11, "name": "Widget", "code": "W-1"}}`,
+			},
+			sent: sent,
+		},
+	})
+
+	product := Product{Name: "Widget", Code: "W-1", Price: 19.99, Currency: "USD"}
+	if err := client.CreateProduct(&product); err != nil {
+		t.Errorf("Unexpected error creating product: %+v", err)
+		return
+	}
+
+	if product.ID != 11 {
+		t.Errorf("Failed to create product. Expected ID to be 11; got %d", product.ID)
+	}
+	expected := `{"code":"W-1","name":"Widget","prices":[{"price":19.99,"currency":"USD"}]}`
+	if actual := sent["http://base/v1/products?api_token=abc123"]; actual != expected {
+		t.Errorf("Create body want %s; got %s", expected, actual)
+	}
+
+	delete(sent, "http://base/v1/products?api_token=abc123")
+	if err := client.CreateProduct(&Product{Name: "Widget", Price: 19.99}); err == nil {
+		t.Error("Expected error creating product with a price and no currency")
+	}
+	if _, ok := sent["http://base/v1/products?api_token=abc123"]; ok {
+		t.Error("Expected no request for a product with a price and no currency")
+	}
+}
+
+func Test_ListProducts(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/products?api_token=abc123&limit=1&start=0": productsResp,
+			},
+		},
+	})
+
+	products, pagination, err := client.ListProducts(0, 1)
+	if err != nil {
+		t.Errorf("Unexpected error listing products: %+v", err)
+		return
+	}
+
+	if !pagination.MoreItems || pagination.NextStart != 1 {
+		t.Errorf("Expected more products starting at 1; got %+v", pagination)
+	}
+	if len(products) != 1 {
+		t.Errorf("Expected 1 product; got %d", len(products))
+		return
+	}
+	p := products[0]
+	if p.ID != 11 || p.Name != "Widget" || p.Code != "W-1" || p.Price != 19.99 || p.Currency != "USD" {
+		t.Errorf("Unexpected product: %+v", p)
+	}
+}
+
+func Test_AddDealProduct(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1/products?api_token=abc123": `{"success": true, "data": {"id": 21, "deal_id": 1, "product_id": 11, "item_price": 19.99, "quantity": 2, "sum": 39.98}}`,
+			},
+			sent: sent,
+		},
+	})
+
+	id, err := client.AddDealProduct(1, 11, 2, 19.99)
+	if err != nil {
+		t.Errorf("Unexpected error adding deal product: %+v", err)
+		return
+	}
+
+	if id != 21 {
+		t.Errorf("Expected attachment ID 21; got %d", id)
+	}
+	expected := `{"item_price":19.99,"product_id":11,"quantity":2}`
+	if actual := sent["http://base/v1/deals/1/products?api_token=abc123"]; actual != expected {
+		t.Errorf("Attach body want %s; got %s", expected, actual)
+	}
+
+	if _, err = client.AddDealProduct(1, 11, 0, 19.99); err == nil {
+		t.Error("Expected error adding a deal product with no quantity")
+	}
+}
+
+func Test_ListDealProducts(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/deals/1/products?api_token=abc123&start=0": dealProductsResp,
+				"http://base/v1/deals/2/products?api_token=abc123&start=0": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	products, err := client.ListDealProducts(1)
+	if err != nil {
+		t.Errorf("Unexpected error listing deal products: %+v", err)
+		return
+	}
+
+	expected := []DealProduct{
+		{ID: 21, ProductID: 11, Name: "Widget", Quantity: 2, ItemPrice: 19.99, Sum: 39.98},
+		{ID: 22, ProductID: 12, Name: "Gadget", Quantity: 1, ItemPrice: 5, Sum: 5},
+	}
+	if len(products) != len(expected) {
+		t.Errorf("Expected %d deal products; got %d", len(expected), len(products))
+		return
+	}
+	for i := range expected {
+		if products[i] != expected[i] {
+			t.Errorf("Deal product want %+v; got %+v", expected[i], products[i])
+		}
+	}
+
+	products, err = client.ListDealProducts(2)
+	if err != nil || len(products) != 0 {
+		t.Errorf("Expected no deal products; got %d (%+v)", len(products), err)
+	}
+}
+
+const dealProductsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 21,
+			"deal_id": 1,
+			"order_nr": 1,
+			"product_id": 11,
+			"item_price": 19.99,
+			"sum": 39.98,
+			"currency": "USD",
+			"quantity": 2,
+			"name": "Widget",
+			"product": {
+				"id": 11,
+				"name": "Widget",
+				"code": "W-1"
+			}
+		},
+		{
+			"id": 22,
+			"deal_id": 1,
+			"order_nr": 2,
+			"item_price": 5,
+			"sum": 5,
+			"currency": "USD",
+			"quantity": 1,
+			"product": {
+				"id": 12,
+				"name": "Gadget",
+				"code": "G-1"
+			}
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 100,
+			"more_items_in_collection": false
+		}
+	}
+}`
+
+const productsResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 11,
+			"name": "Widget",
+			"code": "W-1",
+			"unit": "pcs",
+			"tax": 0,
+			"active_flag": true,
+			"selectable": true,
+			"visible_to": "3",
+			"prices": [
+				{
+					"id": 1,
+					"product_id": 11,
+					"price": 19.99,
+					"currency": "USD",
+					"cost": 0,
+					"overhead_cost": null
+				}
+			]
+		}
+	],
+	"additional_data": {
+		"pagination": {
+			"start": 0,
+			"limit": 1,
+			"more_items_in_collection": true,
+			"next_start": 1
+		}
+	}
+}`
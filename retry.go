@@ -0,0 +1,77 @@
+package pipedrive
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryBackoff is the delay before the first retry, doubling with each
+// further retry
+const DefaultRetryBackoff = 500 * time.Millisecond
+
+// maxRetryAfter caps the delay a Retry-After header can ask for
+const maxRetryAfter = time.Minute
+
+// DefaultIsRetryable retries responses PipeDrive may answer differently later:
+// rate limited requests, and server errors for idempotent requests. A POST
+// failing with a server error may have been applied anyway, so sending it
+// again could create a duplicate.
+func DefaultIsRetryable(resp *http.Response, err error) bool {
+	if err != nil || resp == nil {
+		return false
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= 500 && resp.Request != nil && isIdempotent(resp.Request.Method)
+}
+
+// isIdempotent reports whether sending a request with the method twice has the
+// same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a request that was attempted retries+1 times
+// should be sent again
+func (c *Client) shouldRetry(retries int, resp *http.Response, err error) bool {
+	if retries >= c.MaxRetries {
+		return false
+	}
+	isRetryable := c.IsRetryable
+	if isRetryable == nil {
+		isRetryable = DefaultIsRetryable
+	}
+	return isRetryable(resp, err)
+}
+
+// retryDelay is how long to wait before sending a request again, honoring
+// a Retry-After header given in seconds up to maxRetryAfter
+func retryDelay(retries int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if delay := time.Duration(seconds) * time.Second; seconds < int(maxRetryAfter/time.Second) {
+				return delay
+			}
+			return maxRetryAfter
+		}
+	}
+	return DefaultRetryBackoff << uint(retries)
+}
+
+// discardResponse drains and closes the body of a response that won't be read
+// so its connection can be reused
+func discardResponse(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
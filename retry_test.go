@@ -0,0 +1,162 @@
+package pipedrive
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Retry(t *testing.T) {
+	var stats []RequestStats
+	seq := &sequenceClient{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}}
+	clock := &fakeClock{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: seq,
+		MaxRetries: 3,
+		OnRequest: func(s RequestStats) {
+			stats = append(stats, s)
+		},
+		Clock: clock,
+	})
+
+	if err := client.UpdateDeal(1, map[string]interface{}{"title": "Retried"}); err != nil {
+		t.Errorf("Unexpected error updating deal: %+v", err)
+		return
+	}
+
+	if len(seq.bodies) != 3 || seq.bodies[2] != `{"title":"Retried"}` {
+		t.Errorf("Expected the body to be sent 3 times; got %q", seq.bodies)
+	}
+	expected := []time.Duration{DefaultRetryBackoff, 2 * time.Second}
+	if len(clock.slept) != 2 || clock.slept[0] != expected[0] || clock.slept[1] != expected[1] {
+		t.Errorf("Delays want %v; got %v", expected, clock.slept)
+	}
+	if len(stats) != 1 || stats[0].RetryCount != 2 || stats[0].StatusCode != http.StatusOK {
+		t.Errorf("Expected one report after 2 retries; got %+v", stats)
+	}
+}
+
+func Test_Retry_GivesUp(t *testing.T) {
+	seq := &sequenceClient{statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK}}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: seq,
+		MaxRetries: 1,
+		Clock:      &fakeClock{},
+	})
+
+	err := client.UpdateDeal(1, map[string]interface{}{"title": "Retried"})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected bad gateway API error; got %+v", err)
+	}
+	if len(seq.bodies) != 2 {
+		t.Errorf("Expected 2 attempts; got %d", len(seq.bodies))
+	}
+}
+
+func Test_Retry_IsRetryable(t *testing.T) {
+	seq := &sequenceClient{
+		statuses: []int{0, http.StatusTooManyRequests, http.StatusOK},
+	}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: seq,
+		MaxRetries: 3,
+		IsRetryable: func(resp *http.Response, err error) bool {
+			return err != nil
+		},
+		Clock: &fakeClock{},
+	})
+
+	err := client.UpdateDeal(1, map[string]interface{}{"title": "Retried"})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected rate limit API error; got %+v", err)
+	}
+	if len(seq.bodies) != 2 {
+		t.Errorf("Expected network error to be retried once; got %d attempts", len(seq.bodies))
+	}
+}
+
+func Test_Retry_NotIdempotent(t *testing.T) {
+	seq := &sequenceClient{statuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK}}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: seq,
+		MaxRetries: 3,
+		Clock:      &fakeClock{},
+	})
+
+	err := client.CreateDeal(&Deal{Title: "Close this deal!", PersonID: 1})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected bad gateway API error; got %+v", err)
+	}
+	if len(seq.bodies) != 2 {
+		t.Errorf("Expected a rate limited POST to be retried but not a failed one; got %d attempts", len(seq.bodies))
+	}
+}
+
+func Test_retryDelay(t *testing.T) {
+	for retryAfter, expected := range map[string]time.Duration{
+		"":           DefaultRetryBackoff << 2,
+		"2":          2 * time.Second,
+		"86400":      maxRetryAfter,
+		"9999999999": maxRetryAfter,
+	} {
+		resp := &http.Response{Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		if actual := retryDelay(2, resp); actual != expected {
+			t.Errorf("Delay for Retry-After %q want %s; got %s", retryAfter, expected, actual)
+		}
+	}
+}
+
+// fakeClock is a Clock that only advances when slept on, recording each wait
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.slept = append(c.slept, d)
+	c.now = c.now.Add(d)
+}
+
+// sequenceClient answers each request with the next of statuses, failing with
+// a network error for a status of 0
+type sequenceClient struct {
+	fakeClient
+	statuses []int
+	bodies   []string
+}
+
+func (c *sequenceClient) Do(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+	}
+	c.bodies = append(c.bodies, body)
+
+	status := c.statuses[0]
+	c.statuses = c.statuses[1:]
+	if status == 0 {
+		return nil, errors.New("connection reset")
+	}
+
+	header := http.Header{}
+	if status == http.StatusTooManyRequests {
+		header.Set("Retry-After", "2")
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Request:    req,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"success": true, "data": {"id": 1}}`)),
+	}, nil
+}
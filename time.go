@@ -0,0 +1,43 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// timeLayout is the layout of PipeDrive timestamps, which are in UTC
+const timeLayout = "2006-01-02 15:04:05"
+
+// PipedriveTime is a timestamp in PipeDrive's "2006-01-02 15:04:05" UTC
+// format. Null and empty timestamps decode to the zero time.
+type PipedriveTime struct {
+	time.Time
+}
+
+// UnmarshalJSON parses a PipeDrive timestamp
+func (t *PipedriveTime) UnmarshalJSON(b []byte) error {
+	var value *string
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	if value == nil || *value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(timeLayout, *value, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON formats the timestamp the way PipeDrive expects it, or null for
+// the zero time
+func (t PipedriveTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(timeLayout))
+}
@@ -0,0 +1,45 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_PipedriveTime(t *testing.T) {
+	var parsed struct {
+		CreatedAt PipedriveTime `json:"add_time"`
+		UpdatedAt PipedriveTime `json:"update_time"`
+		DoneTime  PipedriveTime `json:"marked_as_done_time"`
+	}
+	err := json.Unmarshal([]byte(`{"add_time": "2017-11-14 17:19:21", "update_time": null, "marked_as_done_time": ""}`), &parsed)
+	if err != nil {
+		t.Errorf("Unexpected error parsing timestamps: %+v", err)
+		return
+	}
+
+	expected := time.Date(2017, 11, 14, 17, 19, 21, 0, time.UTC)
+	if !parsed.CreatedAt.Equal(expected) {
+		t.Errorf("Timestamp want %s; got %s", expected, parsed.CreatedAt)
+	}
+	if !parsed.UpdatedAt.IsZero() || !parsed.DoneTime.IsZero() {
+		t.Errorf("Expected empty timestamps to be zero; got %s and %s", parsed.UpdatedAt, parsed.DoneTime)
+	}
+
+	out, err := json.Marshal(parsed)
+	if err != nil {
+		t.Errorf("Unexpected error formatting timestamps: %+v", err)
+		return
+	}
+	expectedJSON := `{"add_time":"2017-11-14 17:19:21","update_time":null,"marked_as_done_time":null}`
+	if string(out) != expectedJSON {
+		t.Errorf("Timestamps want %s; got %s", expectedJSON, out)
+	}
+}
+
+func Test_PipedriveTime_Invalid(t *testing.T) {
+	var parsed PipedriveTime
+	if err := json.Unmarshal([]byte(`"14/11/2017"`), &parsed); err == nil {
+		t.Error("Expected error parsing an invalid timestamp")
+	}
+}
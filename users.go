@@ -0,0 +1,48 @@
+package pipedrive
+
+import (
+	"errors"
+	"net/url"
+)
+
+// User is a PipeDrive User representation
+type User struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Active bool   `json:"active_flag"`
+}
+
+// FindUserByEmail searches for a User by email, returning ErrNotFound when
+// there is no match
+func (c *Client) FindUserByEmail(email string) (*User, error) {
+	if email == "" {
+		return nil, errors.New("Must have an email")
+	}
+
+	var found []User
+	_, err := c.getEntity("/users/find?search_by_email=1&term="+url.QueryEscape(email), &found)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, ErrNotFound
+	}
+	return &found[0], nil
+}
+
+// ListUsers returns all Users of the company, active or not
+func (c *Client) ListUsers() ([]User, error) {
+	var users []User
+	_, err := c.getEntity("/users", &users)
+	return users, err
+}
+
+// Ping checks that PipeDrive is reachable and accepts the API token by
+// fetching the token's User. A rejected token returns an *APIError.
+func (c *Client) Ping() error {
+	var me *User
+	_, err := c.getEntity("/users/me", &me)
+	return err
+}
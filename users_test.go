@@ -0,0 +1,126 @@
+package pipedrive
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_FindUserByEmail(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/users/find?api_token=abc123&search_by_email=1&term=chris%40videofruit.com":  usersResp,
+				"http://base/v1/users/find?api_token=abc123&search_by_email=1&term=nobody%40videofruit.com": `{"success": true, "data": null}`,
+			},
+		},
+	})
+
+	user, err := client.FindUserByEmail("chris@videofruit.com")
+	if err != nil {
+		t.Errorf("Unexpected error finding user: %+v", err)
+		return
+	}
+	expected := User{ID: 3219426, Name: "Chris Marshall", Email: "chris@videofruit.com", Active: true}
+	if *user != expected {
+		t.Errorf("User want %+v; got %+v", expected, *user)
+	}
+
+	if _, err = client.FindUserByEmail("nobody@videofruit.com"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound; got %+v", err)
+	}
+}
+
+func Test_ListUsers(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/users?api_token=abc123": usersListResp,
+			},
+		},
+	})
+
+	users, err := client.ListUsers()
+	if err != nil {
+		t.Errorf("Unexpected error listing users: %+v", err)
+		return
+	}
+
+	if len(users) != 2 {
+		t.Errorf("Expected 2 users; got %d", len(users))
+		return
+	}
+	if !users[0].Active || users[1].Active {
+		t.Errorf("Unexpected active flags: %+v", users)
+	}
+	if users[1].Email != "former@videofruit.com" {
+		t.Errorf("Unexpected user: %+v", users[1])
+	}
+}
+
+func Test_Ping(t *testing.T) {
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/users/me?api_token=abc123": `{"success": true, "data": {"id": 3219426, "name": "Chris Marshall", "email": "chris@videofruit.com", "active_flag": true}}`,
+				"http://base/v1/users/me?api_token=bad":    `{"success": false, "error": "unauthorized access", "errorCode": 401}`,
+			},
+			statuses: map[string]int{
+				"http://base/v1/users/me?api_token=bad": http.StatusUnauthorized,
+			},
+		},
+	})
+
+	if err := client.Ping(); err != nil {
+		t.Errorf("Unexpected error pinging: %+v", err)
+	}
+
+	client.SetAPIToken("bad")
+	err := client.Ping()
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected an unauthorized APIError; got %+v", err)
+	}
+}
+
+const usersListResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 3219426,
+			"name": "Chris Marshall",
+			"email": "chris@videofruit.com",
+			"active_flag": true,
+			"is_admin": true
+		},
+		{
+			"id": 3219427,
+			"name": "Former Rep",
+			"email": "former@videofruit.com",
+			"active_flag": false,
+			"is_admin": false
+		}
+	]
+}`
+
+const usersResp = `{
+	"success": true,
+	"data": [
+		{
+			"id": 3219426,
+			"name": "Chris Marshall",
+			"default_currency": "USD",
+			"locale": "en_US",
+			"lang": 1,
+			"email": "chris@videofruit.com",
+			"phone": null,
+			"activated": true,
+			"last_login": "2017-11-17 09:00:00",
+			"created": "2017-11-14 17:00:00",
+			"modified": "2017-11-17 09:00:00",
+			"has_created_company": true,
+			"active_flag": true,
+			"timezone_name": "America/Chicago",
+			"is_admin": true,
+			"role_id": 1
+		}
+	]
+}`
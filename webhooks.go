@@ -0,0 +1,134 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Webhook is a PipeDrive webhook subscription
+type Webhook struct {
+	ID              int    `json:"id"`
+	SubscriptionURL string `json:"subscription_url"`
+	EventAction     string `json:"event_action"`
+	EventObject     string `json:"event_object"`
+}
+
+// CreateWebhook subscribes subscriptionURL to events, e.g. "updated" and
+// "deal", or "*" for any action or object
+func (c *Client) CreateWebhook(eventAction, eventObject, subscriptionURL string) (*Webhook, error) {
+	u, err := url.Parse(subscriptionURL)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return nil, fmt.Errorf("Webhook subscription URL %q must be absolute", subscriptionURL)
+	}
+	if eventAction == "" || eventObject == "" {
+		return nil, errors.New("Webhook requires an event action and object")
+	}
+
+	var webhook Webhook
+	err = c.post("/webhooks", map[string]string{
+		"subscription_url": subscriptionURL,
+		"event_action":     eventAction,
+		"event_object":     eventObject,
+	}, &webhook)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks returns the webhooks of the company
+func (c *Client) ListWebhooks() ([]Webhook, error) {
+	var webhooks []Webhook
+	_, err := c.getEntity("/webhooks", &webhooks)
+	return webhooks, err
+}
+
+// DeleteWebhook removes a webhook subscription
+func (c *Client) DeleteWebhook(id int) error {
+	if id < 1 {
+		return errors.New("Webhook ID must be positive")
+	}
+	// PipeDrive responds to webhook deletions without any data
+	_, err := c.sendNoData(http.MethodDelete, fmt.Sprintf("/webhooks/%d", id), nil)
+	return err
+}
+
+// WebhookEvent is a decoded PipeDrive webhook payload
+type WebhookEvent struct {
+	Event    string
+	Action   string
+	Object   string
+	Current  map[string]interface{}
+	Previous map[string]interface{}
+	Meta     map[string]interface{}
+	// ObjectID and UserID are the IDs of the changed object and of the User
+	// who changed it, taken from Meta
+	ObjectID int
+	UserID   int
+}
+
+// ParseWebhook decodes the body of a webhook request sent by PipeDrive
+func ParseWebhook(body []byte) (*WebhookEvent, error) {
+	var payload struct {
+		Event    string                 `json:"event"`
+		Current  map[string]interface{} `json:"current"`
+		Previous map[string]interface{} `json:"previous"`
+		Meta     json.RawMessage        `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Event == "" {
+		return nil, errors.New("Webhook payload has no event")
+	}
+
+	event := WebhookEvent{
+		Event:    payload.Event,
+		Current:  payload.Current,
+		Previous: payload.Previous,
+	}
+	if len(payload.Meta) > 0 {
+		if err := json.Unmarshal(payload.Meta, &event.Meta); err != nil {
+			return nil, err
+		}
+		// The meta IDs are read from exact numbers rather than the float64s
+		// of Meta, which may round large IDs
+		meta, err := decodeMap(payload.Meta)
+		if err != nil {
+			return nil, err
+		}
+		if event.ObjectID, err = getInt(meta, "id"); err != nil {
+			return nil, err
+		}
+		if event.UserID, err = getInt(meta, "user_id"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Events are named "<action>.<object>", e.g. "updated.deal"
+	parts := strings.SplitN(event.Event, ".", 2)
+	event.Action = parts[0]
+	if len(parts) == 2 {
+		event.Object = parts[1]
+	}
+
+	return &event, nil
+}
+
+// DecodeCurrent unmarshals the current state of the webhook's object into
+// out, e.g. a *Deal for "updated.deal" events
+func (e *WebhookEvent) DecodeCurrent(out interface{}) error {
+	if e.Current == nil {
+		return errors.New("Webhook event has no current object")
+	}
+
+	raw, err := json.Marshal(e.Current)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
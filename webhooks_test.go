@@ -0,0 +1,167 @@
+package pipedrive
+
+import (
+	"testing"
+)
+
+func Test_Webhooks(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/webhooks?api_token=abc123":   webhooksResp,
+				"http://base/v1/webhooks/7?api_token=abc123": `{"status": "ok", "success": true}`,
+			},
+			sent: sent,
+		},
+	})
+
+	webhooks, err := client.ListWebhooks()
+	if err != nil {
+		t.Errorf("Unexpected error listing webhooks: %+v", err)
+		return
+	}
+	expected := Webhook{ID: 7, SubscriptionURL: "https://example.com/hook", EventAction: "updated", EventObject: "deal"}
+	if len(webhooks) != 1 || webhooks[0] != expected {
+		t.Errorf("Webhooks want [%+v]; got %+v", expected, webhooks)
+	}
+
+	if err = client.DeleteWebhook(7); err != nil {
+		t.Errorf("Unexpected error deleting webhook: %+v", err)
+	}
+	if err = client.DeleteWebhook(0); err == nil {
+		t.Error("Expected error deleting webhook with no ID")
+	}
+}
+
+func Test_CreateWebhook(t *testing.T) {
+	sent := map[string]string{}
+	client := NewClient("http://base", "abc123", ClientOptions{
+		HTTPClient: fakeClient{
+			reqs: map[string]string{
+				"http://base/v1/webhooks?api_token=abc123": `{"status": "ok", "success": true, "data": {"id": 7, "subscription_url": "https://example.com/hook", "event_action": "updated", "event_object": "deal"}}`,
+			},
+			sent: sent,
+		},
+	})
+
+	webhook, err := client.CreateWebhook("updated", "deal", "https://example.com/hook")
+	if err != nil {
+		t.Errorf("Unexpected error creating webhook: %+v", err)
+		return
+	}
+	if webhook.ID != 7 {
+		t.Errorf("Expected webhook ID 7; got %d", webhook.ID)
+	}
+	expected := `{"event_action":"updated","event_object":"deal","subscription_url":"https://example.com/hook"}`
+	if actual := sent["http://base/v1/webhooks?api_token=abc123"]; actual != expected {
+		t.Errorf("Webhook body want %s; got %s", expected, actual)
+	}
+
+	for _, u := range []string{"/hook", "example.com/hook", "https://"} {
+		if _, err = client.CreateWebhook("updated", "deal", u); err == nil {
+			t.Errorf("Expected error creating webhook for %q", u)
+		}
+	}
+}
+
+func Test_ParseWebhook(t *testing.T) {
+	event, err := ParseWebhook([]byte(dealUpdatedWebhook))
+	if err != nil {
+		t.Errorf("Unexpected error parsing webhook: %+v", err)
+		return
+	}
+
+	if event.Event != "updated.deal" || event.Action != "updated" || event.Object != "deal" {
+		t.Errorf("Unexpected webhook event: %+v", event)
+	}
+	if event.Previous["stage_id"] != float64(1) {
+		t.Errorf("Expected previous stage 1; got %v", event.Previous["stage_id"])
+	}
+	if value, ok := event.Current["value"].(float64); !ok || value != 1000 {
+		t.Errorf("Expected current value to be a float64 1000; got %#v", event.Current["value"])
+	}
+	if event.ObjectID != 1 || event.UserID != 3219426 {
+		t.Errorf("Expected meta IDs to be decoded; got %d and %d", event.ObjectID, event.UserID)
+	}
+
+	var deal Deal
+	if err = event.DecodeCurrent(&deal); err != nil {
+		t.Errorf("Unexpected error decoding current deal: %+v", err)
+		return
+	}
+	if deal.ID != 1 || deal.StageID != 3 || deal.Title != "Close this deal!" {
+		t.Errorf("Unexpected current deal: %+v", deal)
+	}
+
+	event.Current["title"] = "Edited"
+	if err = event.DecodeCurrent(&deal); err != nil || deal.Title != "Edited" {
+		t.Errorf("Expected edits to Current to be decoded; got %q (%+v)", deal.Title, err)
+	}
+}
+
+func Test_ParseWebhook_Invalid(t *testing.T) {
+	for _, body := range []string{`not json`, `{"current": {}}`, `{"event": 5}`} {
+		if _, err := ParseWebhook([]byte(body)); err == nil {
+			t.Errorf("Expected error parsing webhook %s", body)
+		}
+	}
+}
+
+const dealUpdatedWebhook = `{
+	"v": 1,
+	"matches_filters": {
+		"current": []
+	},
+	"meta": {
+		"v": 1,
+		"action": "updated",
+		"object": "deal",
+		"id": 1,
+		"company_id": 2178381,
+		"user_id": 3219426,
+		"host": "videofruit.pipedrive.com",
+		"timestamp": 1510913521,
+		"permitted_user_ids": [3219426]
+	},
+	"current": {
+		"id": 1,
+		"title": "Close this deal!",
+		"value": 1000,
+		"user_id": 3219426,
+		"person_id": 1,
+		"org_id": 1,
+		"stage_id": 3,
+		"status": "open"
+	},
+	"previous": {
+		"id": 1,
+		"title": "Close this deal!",
+		"value": 1000,
+		"user_id": 3219426,
+		"person_id": 1,
+		"org_id": 1,
+		"stage_id": 1,
+		"status": "open"
+	},
+	"event": "updated.deal",
+	"retry": 0
+}`
+
+const webhooksResp = `{
+	"status": "ok",
+	"success": true,
+	"data": [
+		{
+			"id": 7,
+			"company_id": 1,
+			"owner_id": 3219426,
+			"user_id": 3219426,
+			"event_action": "updated",
+			"event_object": "deal",
+			"subscription_url": "https://example.com/hook",
+			"is_active": 1,
+			"add_time": "2017-11-20T10:00:00.000Z"
+		}
+	]
+}`